@@ -0,0 +1,93 @@
+package compiler
+
+import "encoding/binary"
+
+type Opcode byte
+
+const (
+	OP_CONST Opcode = iota
+	OP_LOAD
+	OP_STORE
+	OP_ADD
+	OP_SUB
+	OP_MUL
+	OP_DIV
+	OP_POW
+	OP_MOD
+	OP_CMP_EQ
+	OP_CMP_NE
+	OP_CMP_LT
+	OP_CMP_GT
+	OP_CMP_LTE
+	OP_CMP_GTE
+	OP_CALL
+	OP_UNIT_CONVERT
+	OP_JUMP
+	OP_JUMP_IF_FALSE
+	OP_RETURN
+	OP_POP
+)
+
+type definition struct {
+	name          string
+	operandWidths []int
+}
+
+var definitions = map[Opcode]definition{
+	OP_CONST:         {"OP_CONST", []int{2}},
+	OP_LOAD:          {"OP_LOAD", []int{2}},
+	OP_STORE:         {"OP_STORE", []int{2}},
+	OP_ADD:           {"OP_ADD", []int{}},
+	OP_SUB:           {"OP_SUB", []int{}},
+	OP_MUL:           {"OP_MUL", []int{}},
+	OP_DIV:           {"OP_DIV", []int{}},
+	OP_POW:           {"OP_POW", []int{}},
+	OP_MOD:           {"OP_MOD", []int{}},
+	OP_CMP_EQ:        {"OP_CMP_EQ", []int{}},
+	OP_CMP_NE:        {"OP_CMP_NE", []int{}},
+	OP_CMP_LT:        {"OP_CMP_LT", []int{}},
+	OP_CMP_GT:        {"OP_CMP_GT", []int{}},
+	OP_CMP_LTE:       {"OP_CMP_LTE", []int{}},
+	OP_CMP_GTE:       {"OP_CMP_GTE", []int{}},
+	OP_CALL:          {"OP_CALL", []int{2, 1}},
+	OP_UNIT_CONVERT:  {"OP_UNIT_CONVERT", []int{2}},
+	OP_JUMP:          {"OP_JUMP", []int{2}},
+	OP_JUMP_IF_FALSE: {"OP_JUMP_IF_FALSE", []int{2}},
+	OP_RETURN:        {"OP_RETURN", []int{}},
+	OP_POP:           {"OP_POP", []int{}},
+}
+
+// Make encodes a single instruction: the opcode followed by its operands,
+// each zero-padded to the width its definition declares.
+func Make(op Opcode, operands ...int) []byte {
+	def, ok := definitions[op]
+	if !ok {
+		return []byte{}
+	}
+
+	length := 1
+	for _, width := range def.operandWidths {
+		length += width
+	}
+
+	instruction := make([]byte, length)
+	instruction[0] = byte(op)
+
+	offset := 1
+	for i, operand := range operands {
+		width := def.operandWidths[i]
+		switch width {
+		case 2:
+			binary.BigEndian.PutUint16(instruction[offset:], uint16(operand))
+		case 1:
+			instruction[offset] = byte(operand)
+		}
+		offset += width
+	}
+
+	return instruction
+}
+
+func readUint16(instructions []byte) uint16 {
+	return binary.BigEndian.Uint16(instructions)
+}
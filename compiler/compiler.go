@@ -0,0 +1,357 @@
+package compiler
+
+import (
+	"errors"
+	"numerical/evaluator"
+	"numerical/lexer"
+	"numerical/parser"
+)
+
+/* --------------------------------- Bytecode ---------------------------------- */
+
+// Bytecode is the compiled form of a parser.ProgramNode: a flat instruction
+// stream plus the constant pool and symbol-table size it indexes into. The
+// VM runs this instead of re-walking the AST every time a program is
+// re-evaluated.
+type Bytecode struct {
+	Instructions []byte
+	Constants    []evaluator.Object
+	NumSymbols   int
+}
+
+var binaryOpcodes = map[string]Opcode{
+	lexer.ADD: OP_ADD,
+	lexer.SUB: OP_SUB,
+	lexer.MUL: OP_MUL,
+	lexer.DIV: OP_DIV,
+	lexer.POW: OP_POW,
+	lexer.MOD: OP_MOD,
+	lexer.EE:  OP_CMP_EQ,
+	lexer.NE:  OP_CMP_NE,
+	lexer.LT:  OP_CMP_LT,
+	lexer.GT:  OP_CMP_GT,
+	lexer.LTE: OP_CMP_LTE,
+	lexer.GTE: OP_CMP_GTE,
+}
+
+/* --------------------------------- Symbols ------------------------------------ */
+
+// SymbolTable replaces the string-keyed environment.Variables map with a
+// dense slot index the VM can address directly with OP_LOAD/OP_STORE.
+type SymbolTable struct {
+	slots map[string]int
+}
+
+func NewSymbolTable() *SymbolTable {
+	return &SymbolTable{slots: make(map[string]int)}
+}
+
+func (s *SymbolTable) Define(name string) int {
+	if index, ok := s.slots[name]; ok {
+		return index
+	}
+	index := len(s.slots)
+	s.slots[name] = index
+	return index
+}
+
+func (s *SymbolTable) Resolve(name string) (int, bool) {
+	index, ok := s.slots[name]
+	return index, ok
+}
+
+/* -------------------------------- Compiler ------------------------------------ */
+
+type Compiler struct {
+	instructions []byte
+	constants    []evaluator.Object
+	symbols      *SymbolTable
+}
+
+func New() *Compiler {
+	return &Compiler{symbols: NewSymbolTable()}
+}
+
+// Compile turns a parsed program into Bytecode the vm package can Run
+// repeatedly without re-walking the AST on every pass.
+func Compile(program parser.ProgramNode) (*Bytecode, error) {
+	c := New()
+	for i, node := range program.Nodes {
+		if err := c.compileNode(node); err != nil {
+			return nil, err
+		}
+		if i != len(program.Nodes)-1 {
+			c.emit(OP_POP)
+		}
+	}
+	if len(program.Nodes) == 0 {
+		c.emit(OP_CONST, c.addConstant(&evaluator.Nil{}))
+	}
+
+	return &Bytecode{
+		Instructions: c.instructions,
+		Constants:    c.constants,
+		NumSymbols:   len(c.symbols.slots),
+	}, nil
+}
+
+func (c *Compiler) emit(op Opcode, operands ...int) int {
+	position := len(c.instructions)
+	c.instructions = append(c.instructions, Make(op, operands...)...)
+	return position
+}
+
+func (c *Compiler) addConstant(object evaluator.Object) int {
+	c.constants = append(c.constants, object)
+	return len(c.constants) - 1
+}
+
+// changeOperand overwrites the 2-byte operand of the instruction at pos,
+// used to back-patch jump targets once they're known.
+func (c *Compiler) changeOperand(pos int, operand int) {
+	op := Opcode(c.instructions[pos])
+	newInstruction := Make(op, operand)
+	copy(c.instructions[pos:], newInstruction)
+}
+
+func (c *Compiler) compileNode(node parser.Node) error {
+	switch n := node.(type) {
+	case *parser.IntNode:
+		c.emit(OP_CONST, c.addConstant(&evaluator.Integer{Value: n.Value}))
+
+	case *parser.FloatNode:
+		c.emit(OP_CONST, c.addConstant(&evaluator.Float{Value: n.Value}))
+
+	case *parser.StringNode:
+		c.emit(OP_CONST, c.addConstant(&evaluator.String{Value: n.Value}))
+
+	case *parser.IdentifierNode:
+		index, ok := c.symbols.Resolve(n.Identifier)
+		if !ok {
+			return errors.New("CompileError: undefined variable " + n.Identifier)
+		}
+		c.emit(OP_LOAD, index)
+
+	case *parser.UnitNode:
+		// Unit conversion depends on the go-units tables the tree-walking
+		// evaluator's convert() already has access to; the VM has no
+		// equivalent, so reject it here rather than emit an OP_UNIT_CONVERT
+		// that can never run, the same way compileForIn rejects non-literal
+		// iterables it can't compile.
+		return errors.New("CompileError: unit-suffixed expressions are not supported by the compiler yet")
+
+	case *parser.AssignNode:
+		if err := c.compileNode(n.Expression); err != nil {
+			return err
+		}
+		c.emit(OP_STORE, c.symbols.Define(n.Identifier))
+
+	case *parser.UnaryOpNode:
+		return c.compileUnaryOp(n)
+
+	case *parser.BinOpNode:
+		return c.compileBinOp(n)
+
+	case *parser.IfNode:
+		return c.compileIf(n)
+
+	case *parser.WhileNode:
+		return c.compileWhile(n)
+
+	case *parser.BlockNode:
+		return c.compileBlock(n)
+
+	case *parser.ForInNode:
+		return c.compileForIn(n)
+
+	case *parser.FunctionDefenitionNode:
+		return c.compileFunctionDefenition(n)
+
+	case *parser.FunctionCallNode:
+		return c.compileFunctionCall(n)
+
+	case *parser.ReturnNode:
+		if n.Expression != nil {
+			if err := c.compileNode(n.Expression); err != nil {
+				return err
+			}
+		} else {
+			c.emit(OP_CONST, c.addConstant(&evaluator.Nil{}))
+		}
+		c.emit(OP_RETURN)
+
+	default:
+		return errors.New("CompileError: " + node.Type() + " is not supported by the compiler yet")
+	}
+
+	return nil
+}
+
+func (c *Compiler) compileUnaryOp(n *parser.UnaryOpNode) error {
+	if err := c.compileNode(n.Right); err != nil {
+		return err
+	}
+
+	switch n.Operation {
+	case lexer.SUB:
+		c.emit(OP_CONST, c.addConstant(&evaluator.Integer{Value: -1}))
+		c.emit(OP_MUL)
+	default:
+		return errors.New("CompileError: unary operator " + n.Operation + " is not supported by the compiler yet")
+	}
+	return nil
+}
+
+func (c *Compiler) compileBinOp(n *parser.BinOpNode) error {
+	if err := c.compileNode(n.Left); err != nil {
+		return err
+	}
+	if err := c.compileNode(n.Right); err != nil {
+		return err
+	}
+
+	op, ok := binaryOpcodes[n.Operation]
+	if !ok {
+		return errors.New("CompileError: operator " + n.Operation + " is not supported by the compiler yet")
+	}
+	c.emit(op)
+	return nil
+}
+
+func (c *Compiler) compileBlock(n *parser.BlockNode) error {
+	for i, statement := range n.Statements {
+		if err := c.compileNode(statement); err != nil {
+			return err
+		}
+		if i != len(n.Statements)-1 {
+			c.emit(OP_POP)
+		}
+	}
+	if len(n.Statements) == 0 {
+		c.emit(OP_CONST, c.addConstant(&evaluator.Nil{}))
+	}
+	return nil
+}
+
+func (c *Compiler) compileIf(n *parser.IfNode) error {
+	if err := c.compileNode(n.Condition); err != nil {
+		return err
+	}
+
+	jumpIfFalsePos := c.emit(OP_JUMP_IF_FALSE, 0xFFFF)
+	if err := c.compileBlock(n.Consequence); err != nil {
+		return err
+	}
+
+	jumpPos := c.emit(OP_JUMP, 0xFFFF)
+	c.changeOperand(jumpIfFalsePos, len(c.instructions))
+
+	if n.Alternative != nil {
+		if err := c.compileBlock(n.Alternative); err != nil {
+			return err
+		}
+	} else {
+		c.emit(OP_CONST, c.addConstant(&evaluator.Nil{}))
+	}
+	c.changeOperand(jumpPos, len(c.instructions))
+
+	return nil
+}
+
+// compileFunctionDefenition compiles a function's body inline in the shared
+// instruction stream, guarded by a jump so normal top-level execution skips
+// over it, and records its entry point in an evaluator.CompiledFunction
+// constant so OP_CALL can jump straight to it.
+func (c *Compiler) compileFunctionDefenition(n *parser.FunctionDefenitionNode) error {
+	slot := c.symbols.Define(n.Identifier)
+
+	jumpOverPos := c.emit(OP_JUMP, 0xFFFF)
+	entryPoint := len(c.instructions)
+
+	paramSlots := make([]int, len(n.Parameters))
+	for i, param := range n.Parameters {
+		identifier, ok := param.(*parser.IdentifierNode)
+		if !ok {
+			return errors.New("CompileError: function parameters must be identifiers")
+		}
+		paramSlots[i] = c.symbols.Define(identifier.Identifier)
+	}
+	// Arguments arrive on the stack in call order, so the last one pushed is
+	// on top - bind them back to front to restore positional order.
+	for i := len(paramSlots) - 1; i >= 0; i-- {
+		c.emit(OP_STORE, paramSlots[i])
+	}
+
+	if err := c.compileBlock(&parser.BlockNode{Statements: n.Consequence.Nodes}); err != nil {
+		return err
+	}
+	c.emit(OP_RETURN)
+	c.changeOperand(jumpOverPos, len(c.instructions))
+
+	function := &evaluator.CompiledFunction{EntryPoint: entryPoint, NumParameters: len(paramSlots)}
+	c.emit(OP_CONST, c.addConstant(function))
+	c.emit(OP_STORE, slot)
+	return nil
+}
+
+func (c *Compiler) compileFunctionCall(n *parser.FunctionCallNode) error {
+	slot, ok := c.symbols.Resolve(n.Identifier)
+	if !ok {
+		return errors.New("CompileError: undefined function " + n.Identifier)
+	}
+	for _, arg := range n.Parameters.Nodes {
+		if err := c.compileNode(arg); err != nil {
+			return err
+		}
+	}
+	c.emit(OP_CALL, slot, len(n.Parameters.Nodes))
+	return nil
+}
+
+// compileForIn only supports a literal array iterable: it unrolls the loop
+// at compile time, since the instruction set has no array length/index
+// opcodes to drive a runtime iteration over an arbitrary Array object.
+func (c *Compiler) compileForIn(n *parser.ForInNode) error {
+	array, ok := n.Iterable.(*parser.ArrayNode)
+	if !ok {
+		return errors.New("CompileError: for-in is only supported over a literal array by the compiler yet")
+	}
+
+	slot := c.symbols.Define(n.Identifier)
+	if len(array.Elements) == 0 {
+		c.emit(OP_CONST, c.addConstant(&evaluator.Nil{}))
+		return nil
+	}
+
+	for i, element := range array.Elements {
+		if err := c.compileNode(element); err != nil {
+			return err
+		}
+		c.emit(OP_STORE, slot)
+		if err := c.compileBlock(n.Body); err != nil {
+			return err
+		}
+		if i != len(array.Elements)-1 {
+			c.emit(OP_POP)
+		}
+	}
+	return nil
+}
+
+func (c *Compiler) compileWhile(n *parser.WhileNode) error {
+	conditionPos := len(c.instructions)
+	if err := c.compileNode(n.Condition); err != nil {
+		return err
+	}
+
+	jumpIfFalsePos := c.emit(OP_JUMP_IF_FALSE, 0xFFFF)
+	if err := c.compileBlock(n.Body); err != nil {
+		return err
+	}
+	c.emit(OP_POP)
+	c.emit(OP_JUMP, conditionPos)
+	c.changeOperand(jumpIfFalsePos, len(c.instructions))
+
+	c.emit(OP_CONST, c.addConstant(&evaluator.Nil{}))
+	return nil
+}
@@ -0,0 +1,95 @@
+package diag
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Diagnostic is a structured, positioned error, replacing the ad-hoc
+// "Kind: message" strings built across the lexer, parser, and evaluator.
+// It mirrors the information go/parser attaches to a token.Pos so callers
+// (the REPL, editor integrations) can render a caret-underlined snippet.
+type Diagnostic struct {
+	Kind    string
+	Message string
+	Pos     int
+	Line    int
+	Col     int
+	Length  int
+	Source  string
+}
+
+// New builds a Diagnostic, deriving Line/Col from Pos against source. Source
+// may be empty when the caller has no access to the original input; Error()
+// then falls back to a header-only message.
+func New(kind string, message string, pos int, source string) Diagnostic {
+	line, col := lineCol(source, pos)
+	return Diagnostic{
+		Kind:    kind,
+		Message: message,
+		Pos:     pos,
+		Line:    line,
+		Col:     col,
+		Length:  1,
+		Source:  source,
+	}
+}
+
+// FromError splits the repo's existing "Kind: message" error convention
+// (e.g. "SyntaxError: unexpected token") into a Diagnostic, so call sites
+// that already return errors.New("XError: ...") gain structured positions
+// without having to be rewritten one by one.
+func FromError(err error, pos int, source string) Diagnostic {
+	kind, message := splitKind(err.Error())
+	return New(kind, message, pos, source)
+}
+
+func splitKind(message string) (string, string) {
+	if idx := strings.Index(message, ": "); idx != -1 {
+		return message[:idx], message[idx+2:]
+	}
+	return "Error", message
+}
+
+func lineCol(source string, pos int) (line int, col int) {
+	line, col = 1, 1
+	for i := 0; i < pos && i < len(source); i++ {
+		if source[i] == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
+
+func (d Diagnostic) Error() string {
+	header := d.Kind + ": " + d.Message + " (" + strconv.Itoa(d.Line) + ":" + strconv.Itoa(d.Col) + ")"
+	snippet := d.snippet()
+	if snippet == "" {
+		return header
+	}
+	return header + "\n" + snippet
+}
+
+func (d Diagnostic) snippet() string {
+	if d.Source == "" || d.Line < 1 {
+		return ""
+	}
+	lines := strings.Split(d.Source, "\n")
+	if d.Line-1 >= len(lines) {
+		return ""
+	}
+
+	length := d.Length
+	if length < 1 {
+		length = 1
+	}
+	indent := d.Col - 1
+	if indent < 0 {
+		indent = 0
+	}
+
+	return lines[d.Line-1] + "\n" + strings.Repeat(" ", indent) + strings.Repeat("^", length)
+}
@@ -0,0 +1,83 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+/* --------------------------------- Builtins ---------------------------------- */
+
+// print writes every argument's String() representation to stdout,
+// space-separated, and returns Nil - the REPL's way of producing output from
+// inside an expression (e.g. `if 1 { print(sq(2)) }`).
+func print(params Program, environment Environment) (Object, error) {
+	args := make([]interface{}, len(params.Objects))
+	for i, object := range params.Objects {
+		args[i] = object.String()
+	}
+	fmt.Println(args...)
+	return &Nil{}, nil
+}
+
+// root computes the nth root of x: root(x, n) = x^(1/n).
+func root(params Program, environment Environment) (Object, error) {
+	if len(params.Objects) != 2 {
+		return &Error{}, errors.New("ArgumentError: root() expects 2 arguments")
+	}
+	x, ok := params.Objects[0].(Number)
+	if !ok {
+		return &Error{}, errors.New("TypeError: root() expects a Number as its first argument")
+	}
+	n, ok := params.Objects[1].(Number)
+	if !ok {
+		return &Error{}, errors.New("TypeError: root() expects a Number as its second argument")
+	}
+	return formatFloat(math.Pow(x.Inspect(), 1/n.Inspect())), nil
+}
+
+// frac approximates a float as a simplified fraction by searching increasing
+// denominators for the first one that reproduces the input within epsilon.
+func frac(params Program, environment Environment) (Object, error) {
+	if len(params.Objects) != 1 {
+		return &Error{}, errors.New("ArgumentError: frac() expects 1 argument")
+	}
+	number, ok := params.Objects[0].(Number)
+	if !ok {
+		return &Error{}, errors.New("TypeError: frac() expects a Number argument")
+	}
+
+	numerator, denominator := approximateFraction(number.Inspect())
+	return &String{Value: fmt.Sprintf("%d/%d", numerator, denominator)}, nil
+}
+
+func approximateFraction(value float64) (int, int) {
+	const maxDenominator = 1000
+	const epsilon = 1e-9
+
+	for denominator := 1; denominator <= maxDenominator; denominator++ {
+		numerator := math.Round(value * float64(denominator))
+		if math.Abs(value-numerator/float64(denominator)) < epsilon {
+			return int(numerator), denominator
+		}
+	}
+	return int(math.Round(value * maxDenominator)), maxDenominator
+}
+
+// lookup looks up a periodic table element by symbol or name and returns its
+// atomic mass, the same way referencing the bare element identifier does.
+func lookup(params Program, environment Environment) (Object, error) {
+	if len(params.Objects) != 1 {
+		return &Error{}, errors.New("ArgumentError: lookup() expects 1 argument")
+	}
+	name, ok := params.Objects[0].(*String)
+	if !ok {
+		return &Error{}, errors.New("TypeError: lookup() expects a String argument")
+	}
+
+	element, err := lookupElements(name.Value, environment.PeriodicTable)
+	if err != nil {
+		return &Error{}, err
+	}
+	return formatFloat(element["atomic_mass"].(float64)), nil
+}
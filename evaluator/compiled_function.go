@@ -0,0 +1,15 @@
+package evaluator
+
+import "numerical/lexer"
+
+// CompiledFunction is the bytecode counterpart of a user-defined function:
+// instead of closing over an Environment like the tree-walker does, it
+// records where its body starts in the shared instruction stream so
+// vm.VM can OP_CALL into it without re-walking the AST.
+type CompiledFunction struct {
+	EntryPoint    int
+	NumParameters int
+}
+
+func (f *CompiledFunction) Type() string   { return lexer.COMPILED_FUNCTION_OBJ }
+func (f *CompiledFunction) String() string { return "<compiled function>" }
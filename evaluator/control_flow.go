@@ -0,0 +1,149 @@
+package evaluator
+
+import (
+	"errors"
+	"numerical/lexer"
+	"numerical/parser"
+)
+
+/* ------------------------------- Return / Break ----------------------------- */
+
+// ReturnValue wraps the result of a `return` statement so evalFunctionCall can
+// unwrap it and short-circuit, instead of always using the last object
+// evaluated in a function's body.
+type ReturnValue struct {
+	Value Object
+}
+
+func (r *ReturnValue) Type() string   { return lexer.RETURN_VALUE_OBJ }
+func (r *ReturnValue) String() string { return r.Value.String() }
+
+type BreakValue struct{}
+
+func (b *BreakValue) Type() string   { return lexer.BREAK_OBJ }
+func (b *BreakValue) String() string { return "break" }
+
+/* ---------------------------------- Array ----------------------------------- */
+
+type Array struct {
+	Elements []Object
+}
+
+func (a *Array) Type() string { return lexer.ARRAY_OBJ }
+func (a *Array) String() string {
+	out := "["
+	for i, element := range a.Elements {
+		if i > 0 {
+			out += ", "
+		}
+		out += element.String()
+	}
+	return out + "]"
+}
+
+/* ------------------------------- Control Flow ------------------------------- */
+
+func evalBlock(block *parser.BlockNode, environment Environment) (Object, error) {
+	child := newChildEnvironment(environment)
+
+	var result Object = &Nil{}
+	for _, statement := range block.Statements {
+		value, err := Eval(statement, child)
+		if err != nil {
+			return &Error{}, err
+		}
+		result = value
+
+		switch value.(type) {
+		case *ReturnValue, *BreakValue:
+			return value, nil
+		}
+	}
+	return result, nil
+}
+
+func evalIf(node *parser.IfNode, environment Environment) (Object, error) {
+	condition, err := Eval(node.Condition, environment)
+	if err != nil {
+		return &Error{}, err
+	}
+
+	if isTruthy(condition) {
+		return evalBlock(node.Consequence, environment)
+	} else if node.Alternative != nil {
+		return evalBlock(node.Alternative, environment)
+	}
+
+	return &Nil{}, nil
+}
+
+func evalWhile(node *parser.WhileNode, environment Environment) (Object, error) {
+	var result Object = &Nil{}
+	for {
+		condition, err := Eval(node.Condition, environment)
+		if err != nil {
+			return &Error{}, err
+		}
+		if !isTruthy(condition) {
+			break
+		}
+
+		value, err := evalBlock(node.Body, environment)
+		if err != nil {
+			return &Error{}, err
+		}
+		if _, ok := value.(*ReturnValue); ok {
+			return value, nil
+		}
+		if _, ok := value.(*BreakValue); ok {
+			break
+		}
+		result = value
+	}
+	return result, nil
+}
+
+func evalForIn(node *parser.ForInNode, environment Environment) (Object, error) {
+	iterable, err := Eval(node.Iterable, environment)
+	if err != nil {
+		return &Error{}, err
+	}
+
+	array, ok := iterable.(*Array)
+	if !ok {
+		return &Error{}, errors.New("ForInError: cannot iterate over type " + iterable.Type())
+	}
+
+	var result Object = &Nil{}
+	for _, element := range array.Elements {
+		child := newChildEnvironment(environment)
+		child.Variables[node.Identifier] = element
+
+		value, err := evalBlock(node.Body, child)
+		if err != nil {
+			return &Error{}, err
+		}
+		if _, ok := value.(*ReturnValue); ok {
+			return value, nil
+		}
+		if _, ok := value.(*BreakValue); ok {
+			break
+		}
+		result = value
+	}
+	return result, nil
+}
+
+func isTruthy(object Object) bool {
+	switch o := object.(type) {
+	case *Integer:
+		return o.Value != 0
+	case *Float:
+		return o.Value != 0
+	case *String:
+		return o.Value != ""
+	case *Nil:
+		return false
+	}
+	return true
+}
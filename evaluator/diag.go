@@ -0,0 +1,12 @@
+package evaluator
+
+import "numerical/diag"
+
+// Diagnose converts one of the evaluator's "Kind: message" errors into a
+// structured diag.Diagnostic for callers (the REPL, editor integrations)
+// that want positions and caret snippets instead of a bare string. pos is
+// the offending Node's Pos field; source is the original program text, if
+// the caller has it, so Diagnostic.Error() can render a caret snippet.
+func Diagnose(err error, pos int, source string) diag.Diagnostic {
+	return diag.FromError(err, pos, source)
+}
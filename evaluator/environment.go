@@ -0,0 +1,62 @@
+package evaluator
+
+import (
+	"numerical/events"
+	"numerical/parser"
+)
+
+/* -------------------------------- Environment ------------------------------ */
+
+type Environment struct {
+	Variables     map[string]Object
+	Functions     map[string]*parser.FunctionDefenitionNode
+	Constants     map[string]Object
+	PeriodicTable map[string]interface{}
+	Parent        *Environment
+	Events        *events.EventPump
+}
+
+// lookup walks the scope chain, checking the current Environment before
+// falling back to its Parent, so blocks can read variables from enclosing
+// scopes without copying them in.
+func (e Environment) lookup(identifier string) (Object, bool) {
+	if value, ok := e.Variables[identifier]; ok {
+		return value, true
+	}
+	if e.Parent != nil {
+		return e.Parent.lookup(identifier)
+	}
+	return nil, false
+}
+
+// lookupFunction walks the scope chain the same way lookup does, so a
+// function defined in an enclosing scope can still be called from inside a
+// nested if/while/for-in block.
+func (e Environment) lookupFunction(identifier string) (*parser.FunctionDefenitionNode, bool) {
+	if fn, ok := e.Functions[identifier]; ok {
+		return fn, true
+	}
+	if e.Parent != nil {
+		return e.Parent.lookupFunction(identifier)
+	}
+	return nil, false
+}
+
+func newChildEnvironment(parent Environment) Environment {
+	return Environment{
+		Variables:     make(map[string]Object),
+		Functions:     make(map[string]*parser.FunctionDefenitionNode),
+		Constants:     parent.Constants,
+		PeriodicTable: parent.PeriodicTable,
+		Parent:        &parent,
+		Events:        parent.Events,
+	}
+}
+
+// postEvent notifies environment.Events, if one is attached, that event
+// occurred. Environments without an EventPump (e.g. in tests) pay nothing.
+func postEvent(environment Environment, event string, source interface{}) {
+	if environment.Events != nil {
+		environment.Events.PostEvent(event, source)
+	}
+}
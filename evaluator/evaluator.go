@@ -21,7 +21,23 @@ func DefineUnits() {
 
 /* --------------------------- Evaluator Functions -------------------------- */
 
+// Eval posts pre-eval/post-eval/error lifecycle events around evalNode so
+// debuggers, tracers, and IDE integrations can observe the tree-walk without
+// patching the evaluator itself.
 func Eval(node parser.Node, environment Environment) (Object, error) {
+	postEvent(environment, "pre-eval", node)
+
+	result, err := evalNode(node, environment)
+	if err != nil {
+		postEvent(environment, "error", err)
+		return result, err
+	}
+
+	postEvent(environment, "post-eval", result)
+	return result, nil
+}
+
+func evalNode(node parser.Node, environment Environment) (Object, error) {
 	switch n := node.(type) {
 	case *parser.ProgramNode:
 		program := Program{}
@@ -31,6 +47,11 @@ func Eval(node parser.Node, environment Environment) (Object, error) {
 				return &Error{}, err
 			}
 			program.Objects = append(program.Objects, result)
+
+			switch result.(type) {
+			case *ReturnValue, *BreakValue:
+				return &program, nil
+			}
 		}
 		return &program, nil
 
@@ -54,6 +75,7 @@ func Eval(node parser.Node, environment Environment) (Object, error) {
 			return &Error{}, err
 		}
 		environment.Variables[n.Identifier] = value
+		postEvent(environment, "assign", n)
 		return &Nil{}, nil
 
 	case *parser.FunctionCallNode:
@@ -73,11 +95,11 @@ func Eval(node parser.Node, environment Environment) (Object, error) {
 			return formatFloat(element["atomic_mass"].(float64)), nil
 		}
 
-		if value, ok := environment.Variables[n.Identifier]; ok {
+		if value, ok := environment.lookup(n.Identifier); ok {
 			return value, nil
 		} else {
 			if len(environment.Variables) < 1 {
-				return &Error{}, errors.New("VarAccessError: Undefined variable identifier " + n.Identifier)
+				return &Error{}, Diagnose(errors.New("VarAccessError: Undefined variable identifier "+n.Identifier), n.Pos, "")
 			}
 
 			maxIdentifier := ""
@@ -102,17 +124,63 @@ func Eval(node parser.Node, environment Environment) (Object, error) {
 
 	case *parser.StringNode:
 		return &String{Value: n.Value}, nil
+
+	case *parser.ArrayNode:
+		elements := make([]Object, 0, len(n.Elements))
+		for _, element := range n.Elements {
+			value, err := Eval(element, environment)
+			if err != nil {
+				return &Error{}, err
+			}
+			elements = append(elements, value)
+		}
+		if matrix, ok := asMatrix(elements); ok {
+			return matrix, nil
+		}
+		return &Array{Elements: elements}, nil
+
+	case *parser.BlockNode:
+		return evalBlock(n, environment)
+
+	case *parser.IfNode:
+		return evalIf(n, environment)
+
+	case *parser.WhileNode:
+		return evalWhile(n, environment)
+
+	case *parser.ForInNode:
+		return evalForIn(n, environment)
+
+	case *parser.ReturnNode:
+		if n.Expression == nil {
+			return &ReturnValue{Value: &Nil{}}, nil
+		}
+		value, err := Eval(n.Expression, environment)
+		if err != nil {
+			return &Error{}, err
+		}
+		return &ReturnValue{Value: value}, nil
+
+	case *parser.BreakNode:
+		return &BreakValue{}, nil
 	}
 
 	return &Error{}, nil
 }
 
 func evalFunctionCall(n *parser.FunctionCallNode, environment Environment) (Object, error) {
+	postEvent(environment, "function-call", n)
+
 	var functions = map[string]func(Program, Environment) (Object, error){
-		"frac":   frac,
-		"print":  print,
-		"root":   root,
-		"lookup": lookup,
+		"frac":      frac,
+		"print":     print,
+		"root":      root,
+		"lookup":    lookup,
+		"det":       det,
+		"inv":       inv,
+		"transpose": transpose,
+		"solve":     solve,
+		"eye":       eye,
 	}
 
 	if function, ok := functions[n.Identifier]; ok {
@@ -125,10 +193,15 @@ func evalFunctionCall(n *parser.FunctionCallNode, environment Environment) (Obje
 			if err != nil {
 				return &Error{}, err
 			}
+			postEvent(environment, "function-return", result)
 			return result, nil
 		}
-	} else if function, ok := environment.Functions[n.Identifier]; ok {
-		env := Environment{Variables: make(map[string]Object), Functions: make(map[string]*parser.FunctionDefenitionNode)}
+	} else if function, ok := environment.lookupFunction(n.Identifier); ok {
+		env := Environment{
+			Variables: make(map[string]Object),
+			Functions: make(map[string]*parser.FunctionDefenitionNode),
+			Events:    environment.Events,
+		}
 		for i, node := range n.Parameters.Nodes {
 			identifer := function.Parameters[i].(*parser.IdentifierNode).Identifier
 			result, err := Eval(node, environment)
@@ -141,10 +214,21 @@ func evalFunctionCall(n *parser.FunctionCallNode, environment Environment) (Obje
 		if err != nil {
 			return &Error{}, err
 		}
-		return result.(*Program).Objects[len(result.(*Program).Objects)-1], nil
+
+		program := result.(*Program)
+		for _, object := range program.Objects {
+			if returnValue, ok := object.(*ReturnValue); ok {
+				postEvent(environment, "function-return", returnValue.Value)
+				return returnValue.Value, nil
+			}
+		}
+
+		returnValue := program.Objects[len(program.Objects)-1]
+		postEvent(environment, "function-return", returnValue)
+		return returnValue, nil
 	}
 
-	return &Error{}, errors.New("FunctionCallError: Function with Identifer " + n.Identifier + " is not defined")
+	return &Error{}, Diagnose(errors.New("FunctionCallError: Function with Identifer "+n.Identifier+" is not defined"), n.Pos, "")
 }
 
 /* ---------------------------- Unary Operations ---------------------------- */
@@ -164,7 +248,7 @@ func evalUnaryOp(node *parser.UnaryOpNode, environment Environment) (Object, err
 		return evalUnaryRound(result)
 	}
 
-	return &Error{}, errors.New("UnaryOperationError: Unsupported " + node.Operation + " Operation")
+	return &Error{}, Diagnose(errors.New("UnaryOperationError: Unsupported "+node.Operation+" Operation"), node.Pos, "")
 }
 
 func evalUnarySub(node Object) (Object, error) {
@@ -223,7 +307,7 @@ func evalBinaryOp(node *parser.BinOpNode, environment Environment) (Object, erro
 		}
 
 	} else if node.Operation == lexer.IN && node.Right.Type() != lexer.IDENTIFIER_NODE {
-		return &Error{}, errors.New("ConversionError: IN cannot convert " + left.Type() + " and " + node.Right.Type())
+		return &Error{}, Diagnose(errors.New("ConversionError: IN cannot convert "+left.Type()+" and "+node.Right.Type()), node.Pos, "")
 	}
 
 	right, err := Eval(node.Right, environment)
@@ -236,16 +320,25 @@ func evalBinaryOp(node *parser.BinOpNode, environment Environment) (Object, erro
 		if right, ok := right.(Number); ok {
 			return handleReturn(evalNumberInfix(left, right, node.Operation))
 		}
+		if right, ok := right.(*Matrix); ok && node.Operation == lexer.MUL {
+			return handleReturn(evalMatrixScalarInfix(right, left, node.Operation))
+		}
 
 	case *String:
 		if right.Type() == lexer.STRING_OBJ {
 			return handleReturn(evalStringInfix(left, right.(*String), node.Operation))
 		}
 
-		//TODO Array Node + possibly others such as matrix
+	case *Matrix:
+		if right, ok := right.(*Matrix); ok {
+			return handleReturn(evalMatrixInfix(left, right, node.Operation))
+		}
+		if right, ok := right.(Number); ok {
+			return handleReturn(evalMatrixScalarInfix(left, right, node.Operation))
+		}
 	}
 
-	return &Error{}, errors.New("BinaryOperationError: Unsupported Types: " + left.Type() + node.Operation + right.Type())
+	return &Error{}, Diagnose(errors.New("BinaryOperationError: Unsupported Types: "+left.Type()+node.Operation+right.Type()), node.Pos, "")
 }
 
 func evalStringInfix(left *String, right *String, operation string) (Object, error) {
@@ -317,6 +410,13 @@ func binaryOperations(left float64, right float64, operation string) float64 {
 
 /* ---------------------------- Helper Functions ---------------------------- */
 
+// NewNumber builds the canonical Number object for value (an Integer when it
+// has no fractional part, a Float otherwise), exported for packages such as
+// compiler/vm that construct Objects from raw float64 results.
+func NewNumber(value float64) Number {
+	return formatFloat(value)
+}
+
 func boolToInt(value bool) int {
 	if value {
 		return 1
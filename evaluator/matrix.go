@@ -0,0 +1,363 @@
+package evaluator
+
+import (
+	"errors"
+	"math"
+	"numerical/lexer"
+	"strconv"
+)
+
+/* ---------------------------------- Matrix ---------------------------------- */
+
+type Matrix struct {
+	Rows int
+	Cols int
+	Data [][]float64
+}
+
+func (m *Matrix) Type() string { return lexer.MATRIX_OBJ }
+func (m *Matrix) String() string {
+	out := "["
+	for i, row := range m.Data {
+		if i > 0 {
+			out += ", "
+		}
+		out += "["
+		for j, value := range row {
+			if j > 0 {
+				out += ", "
+			}
+			out += strconv.FormatFloat(value, 'g', -1, 64)
+		}
+		out += "]"
+	}
+	return out + "]"
+}
+
+// asMatrix promotes a []Object holding only equal-length rows of Number
+// elements (i.e. the evaluation of a nested ArrayNode like [[1,2],[3,4]])
+// into a Matrix, so nested array literals are usable directly as matrices.
+func asMatrix(elements []Object) (*Matrix, bool) {
+	if len(elements) == 0 {
+		return nil, false
+	}
+
+	cols := -1
+	data := make([][]float64, 0, len(elements))
+	for _, element := range elements {
+		row, ok := element.(*Array)
+		if !ok {
+			return nil, false
+		}
+
+		values := make([]float64, 0, len(row.Elements))
+		for _, cell := range row.Elements {
+			number, ok := cell.(Number)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, number.Inspect())
+		}
+
+		if cols == -1 {
+			cols = len(values)
+		} else if cols != len(values) {
+			return nil, false
+		}
+		data = append(data, values)
+	}
+
+	return &Matrix{Rows: len(data), Cols: cols, Data: data}, true
+}
+
+func shapeString(rows, cols int) string {
+	return strconv.Itoa(rows) + "x" + strconv.Itoa(cols)
+}
+
+func cloneMatrixData(data [][]float64) [][]float64 {
+	cloned := make([][]float64, len(data))
+	for i, row := range data {
+		cloned[i] = append([]float64(nil), row...)
+	}
+	return cloned
+}
+
+func identityData(n int) [][]float64 {
+	data := make([][]float64, n)
+	for i := range data {
+		data[i] = make([]float64, n)
+		data[i][i] = 1
+	}
+	return data
+}
+
+/* ------------------------------- Infix Operators ----------------------------- */
+
+func evalMatrixInfix(left *Matrix, right *Matrix, operation string) (Object, error) {
+	switch operation {
+	case lexer.ADD, lexer.SUB:
+		if left.Rows != right.Rows || left.Cols != right.Cols {
+			return &Error{}, errors.New("MatrixShapeError: " + shapeString(left.Rows, left.Cols) + " · " + shapeString(right.Rows, right.Cols))
+		}
+		data := make([][]float64, left.Rows)
+		for i := range data {
+			data[i] = make([]float64, left.Cols)
+			for j := range data[i] {
+				if operation == lexer.ADD {
+					data[i][j] = left.Data[i][j] + right.Data[i][j]
+				} else {
+					data[i][j] = left.Data[i][j] - right.Data[i][j]
+				}
+			}
+		}
+		return &Matrix{Rows: left.Rows, Cols: left.Cols, Data: data}, nil
+
+	case lexer.MUL:
+		if left.Cols != right.Rows {
+			return &Error{}, errors.New("MatrixShapeError: " + shapeString(left.Rows, left.Cols) + " · " + shapeString(right.Rows, right.Cols))
+		}
+		data := make([][]float64, left.Rows)
+		for i := range data {
+			data[i] = make([]float64, right.Cols)
+			for j := 0; j < right.Cols; j++ {
+				var sum float64
+				for k := 0; k < left.Cols; k++ {
+					sum += left.Data[i][k] * right.Data[k][j]
+				}
+				data[i][j] = sum
+			}
+		}
+		return &Matrix{Rows: left.Rows, Cols: right.Cols, Data: data}, nil
+	}
+
+	return &Error{}, errors.New("MatrixOperationError: unsupported operation between matrices " + operation)
+}
+
+func evalMatrixScalarInfix(matrix *Matrix, scalar Number, operation string) (Object, error) {
+	value := scalar.Inspect()
+
+	switch operation {
+	case lexer.MUL, lexer.DIV:
+		data := make([][]float64, matrix.Rows)
+		for i := range data {
+			data[i] = make([]float64, matrix.Cols)
+			for j := range data[i] {
+				if operation == lexer.MUL {
+					data[i][j] = matrix.Data[i][j] * value
+				} else {
+					data[i][j] = matrix.Data[i][j] / value
+				}
+			}
+		}
+		return &Matrix{Rows: matrix.Rows, Cols: matrix.Cols, Data: data}, nil
+
+	case lexer.POW:
+		if matrix.Rows != matrix.Cols {
+			return &Error{}, errors.New("MatrixShapeError: " + shapeString(matrix.Rows, matrix.Cols) + " is not square")
+		}
+		if float64(int(value)) != value || value < 0 {
+			return &Error{}, errors.New("MatrixOperationError: matrix POW requires a non-negative integer exponent")
+		}
+		return matrixPow(matrix, int(value)), nil
+	}
+
+	return &Error{}, errors.New("MatrixOperationError: unsupported operation between matrix and scalar " + operation)
+}
+
+func matrixPow(matrix *Matrix, exponent int) *Matrix {
+	result := &Matrix{Rows: matrix.Rows, Cols: matrix.Cols, Data: identityData(matrix.Rows)}
+	base := matrix
+	for exponent > 0 {
+		if exponent%2 == 1 {
+			product, _ := evalMatrixInfix(result, base, lexer.MUL)
+			result = product.(*Matrix)
+		}
+		squared, _ := evalMatrixInfix(base, base, lexer.MUL)
+		base = squared.(*Matrix)
+		exponent /= 2
+	}
+	return result
+}
+
+/* --------------------------------- Builtins ---------------------------------- */
+
+func asSquareMatrix(params Program, name string) (*Matrix, error) {
+	if len(params.Objects) != 1 {
+		return &Matrix{}, errors.New("ArgumentError: " + name + "() expects 1 argument")
+	}
+	matrix, ok := params.Objects[0].(*Matrix)
+	if !ok {
+		return &Matrix{}, errors.New("TypeError: " + name + "() expects a Matrix argument")
+	}
+	if matrix.Rows != matrix.Cols {
+		return &Matrix{}, errors.New("MatrixShapeError: " + name + "() requires a square matrix, got " + shapeString(matrix.Rows, matrix.Cols))
+	}
+	return matrix, nil
+}
+
+func det(params Program, environment Environment) (Object, error) {
+	matrix, err := asSquareMatrix(params, "det")
+	if err != nil {
+		return &Error{}, err
+	}
+
+	a := cloneMatrixData(matrix.Data)
+	b := make([][]float64, matrix.Rows)
+	for i := range b {
+		b[i] = []float64{0}
+	}
+
+	sign, singular := eliminate(a, b)
+	if singular {
+		return &Integer{Value: 0}, nil
+	}
+
+	result := float64(sign)
+	for i := range a {
+		result *= a[i][i]
+	}
+	return formatFloat(result), nil
+}
+
+func inv(params Program, environment Environment) (Object, error) {
+	matrix, err := asSquareMatrix(params, "inv")
+	if err != nil {
+		return &Error{}, err
+	}
+
+	a := cloneMatrixData(matrix.Data)
+	b := identityData(matrix.Rows)
+
+	if _, singular := eliminate(a, b); singular {
+		return &Error{}, errors.New("MatrixSingularError: matrix is not invertible")
+	}
+
+	return &Matrix{Rows: matrix.Rows, Cols: matrix.Rows, Data: backSubstitute(a, b)}, nil
+}
+
+func transpose(params Program, environment Environment) (Object, error) {
+	if len(params.Objects) != 1 {
+		return &Error{}, errors.New("ArgumentError: transpose() expects 1 argument")
+	}
+	matrix, ok := params.Objects[0].(*Matrix)
+	if !ok {
+		return &Error{}, errors.New("TypeError: transpose() expects a Matrix argument")
+	}
+
+	data := make([][]float64, matrix.Cols)
+	for i := range data {
+		data[i] = make([]float64, matrix.Rows)
+		for j := range data[i] {
+			data[i][j] = matrix.Data[j][i]
+		}
+	}
+	return &Matrix{Rows: matrix.Cols, Cols: matrix.Rows, Data: data}, nil
+}
+
+func solve(params Program, environment Environment) (Object, error) {
+	if len(params.Objects) != 2 {
+		return &Error{}, errors.New("ArgumentError: solve() expects 2 arguments")
+	}
+	matrix, ok := params.Objects[0].(*Matrix)
+	if !ok || matrix.Rows != matrix.Cols {
+		return &Error{}, errors.New("TypeError: solve() expects a square Matrix as its first argument")
+	}
+	vector, ok := params.Objects[1].(*Array)
+	if !ok || len(vector.Elements) != matrix.Rows {
+		return &Error{}, errors.New("TypeError: solve() expects a vector of length " + strconv.Itoa(matrix.Rows) + " as its second argument")
+	}
+
+	a := cloneMatrixData(matrix.Data)
+	b := make([][]float64, matrix.Rows)
+	for i, element := range vector.Elements {
+		number, ok := element.(Number)
+		if !ok {
+			return &Error{}, errors.New("TypeError: solve() expects a vector of Numbers")
+		}
+		b[i] = []float64{number.Inspect()}
+	}
+
+	if _, singular := eliminate(a, b); singular {
+		return &Error{}, errors.New("MatrixSingularError: system has no unique solution")
+	}
+
+	x := backSubstitute(a, b)
+	elements := make([]Object, len(x))
+	for i, row := range x {
+		elements[i] = formatFloat(row[0])
+	}
+	return &Array{Elements: elements}, nil
+}
+
+func eye(params Program, environment Environment) (Object, error) {
+	if len(params.Objects) != 1 {
+		return &Error{}, errors.New("ArgumentError: eye() expects 1 argument")
+	}
+	size, ok := params.Objects[0].(*Integer)
+	if !ok || size.Value <= 0 {
+		return &Error{}, errors.New("TypeError: eye() expects a positive Integer argument")
+	}
+	return &Matrix{Rows: size.Value, Cols: size.Value, Data: identityData(size.Value)}, nil
+}
+
+/* ---------------------------- Gaussian Elimination ---------------------------- */
+
+// eliminate reduces the augmented system [a|b] to row-echelon form in place
+// using Gaussian elimination with partial pivoting, returning the sign of the
+// row-swap permutation (for determinants) and whether the matrix is singular.
+func eliminate(a [][]float64, b [][]float64) (sign int, singular bool) {
+	n := len(a)
+	sign = 1
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			singular = true
+			continue
+		}
+
+		if pivot != col {
+			a[pivot], a[col] = a[col], a[pivot]
+			b[pivot], b[col] = b[col], b[pivot]
+			sign = -sign
+		}
+
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			for k := range b[row] {
+				b[row][k] -= factor * b[col][k]
+			}
+		}
+	}
+
+	return sign, singular
+}
+
+func backSubstitute(a [][]float64, b [][]float64) [][]float64 {
+	n := len(a)
+	m := len(b[0])
+	x := make([][]float64, n)
+	for i := range x {
+		x[i] = make([]float64, m)
+	}
+
+	for row := n - 1; row >= 0; row-- {
+		for col := 0; col < m; col++ {
+			sum := b[row][col]
+			for k := row + 1; k < n; k++ {
+				sum -= a[row][k] * x[k][col]
+			}
+			x[row][col] = sum / a[row][row]
+		}
+	}
+	return x
+}
@@ -0,0 +1,95 @@
+package evaluator
+
+import (
+	"numerical/lexer"
+	"strconv"
+)
+
+/* ---------------------------------- Object ----------------------------------- */
+
+// Object is implemented by every value the evaluator can produce, mirroring
+// parser.Node on the AST side - a tree-walk in, an Object tree out.
+type Object interface {
+	Type() string
+	String() string
+}
+
+// Number is implemented by the object kinds evalNumberInfix and the matrix
+// operators can do arithmetic on (Integer, Float, Unit), so callers don't
+// need a type switch over every numeric-like Object at each call site.
+type Number interface {
+	Object
+	Inspect() float64
+}
+
+/* --------------------------------- Integer/Float ------------------------------ */
+
+type Integer struct{ Value int }
+
+func (i *Integer) Type() string     { return lexer.INT_OBJ }
+func (i *Integer) String() string   { return strconv.Itoa(i.Value) }
+func (i *Integer) Inspect() float64 { return float64(i.Value) }
+
+type Float struct{ Value float64 }
+
+func (f *Float) Type() string     { return lexer.FLOAT_OBJ }
+func (f *Float) String() string   { return strconv.FormatFloat(f.Value, 'g', -1, 64) }
+func (f *Float) Inspect() float64 { return f.Value }
+
+/* ----------------------------------- String ----------------------------------- */
+
+type String struct{ Value string }
+
+func (s *String) Type() string   { return lexer.STRING_OBJ }
+func (s *String) String() string { return s.Value }
+
+/* ------------------------------------ Unit ------------------------------------ */
+
+// Unit pairs a numeric Value with the identifier it was suffixed with (e.g.
+// "5 meters"), so evalNumberInfix/convert can do unit-aware arithmetic
+// without the rest of the evaluator needing to know about go-units.
+type Unit struct {
+	Value float64
+	Unit  string
+}
+
+func (u *Unit) Type() string     { return lexer.UNIT_OBJ }
+func (u *Unit) String() string   { return strconv.FormatFloat(u.Value, 'g', -1, 64) + " " + u.Unit }
+func (u *Unit) Inspect() float64 { return u.Value }
+
+/* ------------------------------------- Nil ------------------------------------ */
+
+type Nil struct{}
+
+func (n *Nil) Type() string   { return lexer.NIL }
+func (n *Nil) String() string { return "nil" }
+
+/* ------------------------------------ Error ------------------------------------ */
+
+// Error is returned alongside a non-nil error as a placeholder Object, since
+// the actual failure detail travels on the Go error return, not the Object.
+type Error struct{}
+
+func (e *Error) Type() string   { return lexer.ERROR }
+func (e *Error) String() string { return "error" }
+
+/* ----------------------------------- Program ----------------------------------- */
+
+// Program is the result of evaluating a parser.ProgramNode: every statement's
+// Object, in order, so callers such as evalFunctionCall can find the final
+// ReturnValue (or, absent one, fall back to the last statement's value).
+type Program struct {
+	Objects []Object
+}
+
+func (p *Program) Type() string { return lexer.PROGRAM_OBJ }
+func (p *Program) String() string {
+	out := ""
+	for i, object := range p.Objects {
+		if i > 0 {
+			out += "\n"
+		}
+		out += object.String()
+	}
+	return out
+}
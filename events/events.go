@@ -0,0 +1,62 @@
+package events
+
+import "sync"
+
+// EventCallback receives the event name and the source it was posted with
+// (a parser Node, an evaluator Object, or the error of an "error" event).
+type EventCallback func(event string, source interface{})
+
+type observer struct {
+	source interface{}
+	cb     EventCallback
+}
+
+// EventPump lets debuggers, tracers, and IDE integrations hook the
+// interpreter without patching Eval: they register a callback for an event
+// name and get called back whenever the evaluator posts that event.
+type EventPump struct {
+	mu        sync.Mutex
+	observers map[string][]observer
+}
+
+func NewEventPump() *EventPump {
+	return &EventPump{observers: make(map[string][]observer)}
+}
+
+func (p *EventPump) AddObserver(event string, source interface{}, cb EventCallback) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.observers[event] = append(p.observers[event], observer{source: source, cb: cb})
+}
+
+func (p *EventPump) RemoveObserver(event string, source interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	observers, ok := p.observers[event]
+	if !ok {
+		return
+	}
+
+	filtered := make([]observer, 0, len(observers))
+	for _, o := range observers {
+		if o.source != source {
+			filtered = append(filtered, o)
+		}
+	}
+	p.observers[event] = filtered
+}
+
+// PostEvent snapshots the observer list for event under the lock, then calls
+// each observer outside the lock. This lets a callback register/unregister
+// observers, or trigger further PostEvent calls, without deadlocking or
+// racing with AddObserver/RemoveObserver.
+func (p *EventPump) PostEvent(event string, source interface{}) {
+	p.mu.Lock()
+	observers := append([]observer(nil), p.observers[event]...)
+	p.mu.Unlock()
+
+	for _, o := range observers {
+		o.cb(event, source)
+	}
+}
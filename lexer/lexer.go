@@ -0,0 +1,149 @@
+package lexer
+
+import "errors"
+
+// Lex scans source into the token stream parser.NewParser consumes, setting
+// each Token's Pos to its byte offset in source so diag.Diagnostic can
+// render real caret-underlined snippets instead of always pointing at 0.
+// Callers do not need to append their own EOF token - Lex always appends one
+// at len(source).
+func Lex(source string) ([]Token, error) {
+	tokens := make([]Token, 0)
+
+	i := 0
+	for i < len(source) {
+		ch := source[i]
+
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n':
+			i++
+
+		case ch == '"':
+			token, width, err := lexString(source, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token)
+			i += width
+
+		case isDigit(ch):
+			token, width := lexNumber(source, i)
+			tokens = append(tokens, token)
+			i += width
+
+		case isIdentifierStart(ch):
+			token, width, err := lexIdentifier(source, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token)
+			i += width
+
+		default:
+			token, width, err := lexOperator(source, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token)
+			i += width
+		}
+	}
+
+	tokens = append(tokens, Token{Type: EOF, Literal: "", Pos: len(source)})
+	return tokens, nil
+}
+
+func lexString(source string, start int) (Token, int, error) {
+	i := start + 1
+	for i < len(source) && source[i] != '"' {
+		i++
+	}
+	if i >= len(source) {
+		return Token{}, 0, errors.New("LexerError: unterminated string literal")
+	}
+	return Token{Type: STRING, Literal: source[start+1 : i], Pos: start}, i + 1 - start, nil
+}
+
+func lexNumber(source string, start int) (Token, int) {
+	i := start
+	for i < len(source) && isDigit(source[i]) {
+		i++
+	}
+
+	tokenType := INT
+	if i < len(source) && source[i] == '.' && i+1 < len(source) && isDigit(source[i+1]) {
+		tokenType = FLOAT
+		i++
+		for i < len(source) && isDigit(source[i]) {
+			i++
+		}
+	}
+
+	return Token{Type: tokenType, Literal: source[start:i], Pos: start}, i - start
+}
+
+func lexIdentifier(source string, start int) (Token, int, error) {
+	i := start
+	for i < len(source) && isIdentifierPart(source[i]) {
+		i++
+	}
+
+	literal := source[start:i]
+	tokenType, err := lookupIdentifier(literal)
+	if err != nil {
+		return Token{}, 0, err
+	}
+	return Token{Type: tokenType, Literal: literal, Pos: start}, i - start, nil
+}
+
+// twoCharOperators is checked before oneCharOperators, since e.g. "==" must
+// not be lexed as two EQ tokens.
+var twoCharOperators = map[string]string{
+	"==": EE,
+	"!=": NE,
+	"<=": LTE,
+	">=": GTE,
+	"=>": ARROW,
+}
+
+var oneCharOperators = map[byte]string{
+	'<': LT,
+	'>': GT,
+	'+': ADD,
+	'-': SUB,
+	'/': DIV,
+	'*': MUL,
+	'%': MOD,
+	'^': POW,
+	'~': TILDE,
+	'!': NOT,
+	'=': EQ,
+	'(': LPAREN,
+	')': RPAREN,
+	'[': LSQUARE,
+	']': RSQUARE,
+	'{': LBRACE,
+	'}': RBRACE,
+	';': SEMICOLON,
+	',': COMMA,
+}
+
+func lexOperator(source string, i int) (Token, int, error) {
+	if i+1 < len(source) {
+		if tokenType, ok := twoCharOperators[source[i:i+2]]; ok {
+			return Token{Type: tokenType, Literal: source[i : i+2], Pos: i}, 2, nil
+		}
+	}
+	if tokenType, ok := oneCharOperators[source[i]]; ok {
+		return Token{Type: tokenType, Literal: string(source[i]), Pos: i}, 1, nil
+	}
+	return Token{}, 0, errors.New("LexerError: unexpected character '" + string(source[i]) + "'")
+}
+
+func isDigit(ch byte) bool { return ch >= '0' && ch <= '9' }
+
+func isIdentifierStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isIdentifierPart(ch byte) bool { return isIdentifierStart(ch) || isDigit(ch) }
@@ -0,0 +1,68 @@
+package lexer
+
+import "testing"
+
+func TestLexSetsRealPositions(t *testing.T) {
+	tokens, err := Lex("1 + 22")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []Token{
+		{Type: INT, Literal: "1", Pos: 0},
+		{Type: ADD, Literal: "+", Pos: 2},
+		{Type: INT, Literal: "22", Pos: 4},
+		{Type: EOF, Literal: "", Pos: 6},
+	}
+	if len(tokens) != len(want) {
+		t.Fatalf("expected %d tokens, got %d: %v", len(want), len(tokens), tokens)
+	}
+	for i, token := range tokens {
+		if token != want[i] {
+			t.Errorf("token %d: expected %+v, got %+v", i, want[i], token)
+		}
+	}
+}
+
+func TestLexKeywordsAndOperators(t *testing.T) {
+	tokens, err := Lex(`define sq(x) => x * x`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantTypes := []string{DEFINE, IDENTIFIER, LPAREN, IDENTIFIER, RPAREN, ARROW, IDENTIFIER, MUL, IDENTIFIER, EOF}
+	if len(tokens) != len(wantTypes) {
+		t.Fatalf("expected %d tokens, got %d: %v", len(wantTypes), len(tokens), tokens)
+	}
+	for i, token := range tokens {
+		if token.Type != wantTypes[i] {
+			t.Errorf("token %d: expected type %s, got %s", i, wantTypes[i], token.Type)
+		}
+	}
+}
+
+func TestLexStringAndFloat(t *testing.T) {
+	tokens, err := Lex(`"hi" 3.5`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tokens[0].Type != STRING || tokens[0].Literal != "hi" {
+		t.Errorf("expected STRING(hi), got %+v", tokens[0])
+	}
+	if tokens[1].Type != FLOAT || tokens[1].Literal != "3.5" {
+		t.Errorf("expected FLOAT(3.5), got %+v", tokens[1])
+	}
+}
+
+func TestLexUnterminatedStringError(t *testing.T) {
+	if _, err := Lex(`"unterminated`); err == nil {
+		t.Fatalf("expected an error for an unterminated string literal")
+	}
+}
+
+func TestLexUnexpectedCharacterError(t *testing.T) {
+	if _, err := Lex("@"); err == nil {
+		t.Fatalf("expected an error for an unexpected character")
+	}
+}
@@ -5,10 +5,11 @@ import "errors"
 type Token struct {
 	Type    string
 	Literal string
+	Pos     int
 }
 
-func NewToken(tokenType string, ch byte) Token {
-	return Token{Type: tokenType, Literal: string(ch)}
+func NewToken(tokenType string, ch byte, pos int) Token {
+	return Token{Type: tokenType, Literal: string(ch), Pos: pos}
 }
 
 func lookupIdentifier(identifier string) (string, error) {
@@ -27,6 +28,12 @@ var keywords = map[string]string{
 	"in":     IN,
 	"define": DEFINE,
 	"per":    DIV,
+	"if":     IF,
+	"else":   ELSE,
+	"while":  WHILE,
+	"for":    FOR,
+	"return": RETURN,
+	"break":  BREAK,
 }
 
 const (
@@ -71,6 +78,13 @@ const (
 	IN     = "IN"
 	DEFINE = "DEFINE"
 
+	IF     = "IF"
+	ELSE   = "ELSE"
+	WHILE  = "WHILE"
+	FOR    = "FOR"
+	RETURN = "RETURN"
+	BREAK  = "BREAK"
+
 	PROGRAM_NODE             = "PROGRAM_NODE"
 	IDENTIFIER_NODE          = "IDENTIFIER_NODE"
 	INT_NODE                 = "INT_NODE"
@@ -83,6 +97,12 @@ const (
 	ARRAY_NODE               = "ARRAY_NODE"
 	ASSIGN_NODE              = "ASSIGN_NODE"
 	FUNCTION_DEFENITION_NODE = "FUNCTION_DEFENITION_NODE"
+	BLOCK_NODE               = "BLOCK_NODE"
+	IF_NODE                  = "IF_NODE"
+	WHILE_NODE               = "WHILE_NODE"
+	FOR_IN_NODE              = "FOR_IN_NODE"
+	RETURN_NODE              = "RETURN_NODE"
+	BREAK_NODE               = "BREAK_NODE"
 
 	INT_OBJ                 = "INT_OBJ"
 	FLOAT_OBJ               = "FLOAT_OBJ"
@@ -96,4 +116,8 @@ const (
 	UNARY_OP_OBJ            = "UNARY_OP_OBJ"
 	ASSIGN_OBJ              = "ASSIGN_OBJ"
 	FUNCTION_DEFENITION_OBJ = "FUNCTION_DEFENITION_OBJ"
+	RETURN_VALUE_OBJ        = "RETURN_VALUE_OBJ"
+	BREAK_OBJ               = "BREAK_OBJ"
+	MATRIX_OBJ              = "MATRIX_OBJ"
+	COMPILED_FUNCTION_OBJ   = "COMPILED_FUNCTION_OBJ"
 )
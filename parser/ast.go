@@ -0,0 +1,109 @@
+package parser
+
+import "numerical/lexer"
+
+/* ------------------------------------ AST ----------------------------------- */
+
+// Node is implemented by every AST node. Each node type carries a Pos field:
+// the byte offset of the node's first token in the original source (for a
+// BinOpNode, of its operator token), used by diag.Diagnostic to render
+// caret-underlined error snippets.
+type Node interface {
+	Type() string
+}
+
+type ErrorNode struct{ Pos int }
+
+func (n *ErrorNode) Type() string { return lexer.ERROR }
+
+type ProgramNode struct {
+	Nodes []Node
+	Pos   int
+}
+
+func (n *ProgramNode) Type() string { return lexer.PROGRAM_NODE }
+
+type IdentifierNode struct {
+	Identifier string
+	Pos        int
+}
+
+func (n *IdentifierNode) Type() string { return lexer.IDENTIFIER_NODE }
+
+type IntNode struct {
+	Value int
+	Pos   int
+}
+
+func (n *IntNode) Type() string { return lexer.INT_NODE }
+
+type FloatNode struct {
+	Value float64
+	Pos   int
+}
+
+func (n *FloatNode) Type() string { return lexer.FLOAT_NODE }
+
+type StringNode struct {
+	Value string
+	Pos   int
+}
+
+func (n *StringNode) Type() string { return lexer.STRING_NODE }
+
+type UnitNode struct {
+	Value Node
+	Unit  string
+	Pos   int
+}
+
+func (n *UnitNode) Type() string { return lexer.UNIT_NODE }
+
+type BinOpNode struct {
+	Left      Node
+	Operation string
+	Right     Node
+	Pos       int
+}
+
+func (n *BinOpNode) Type() string { return lexer.BIN_OP_NODE }
+
+type UnaryOpNode struct {
+	Operation string
+	Right     Node
+	Pos       int
+}
+
+func (n *UnaryOpNode) Type() string { return lexer.UNARY_OP_NODE }
+
+type FunctionCallNode struct {
+	Identifier string
+	Parameters ProgramNode
+	Pos        int
+}
+
+func (n *FunctionCallNode) Type() string { return lexer.FUNCTION_CALL_NODE }
+
+type ArrayNode struct {
+	Elements []Node
+	Pos      int
+}
+
+func (n *ArrayNode) Type() string { return lexer.ARRAY_NODE }
+
+type AssignNode struct {
+	Identifier string
+	Expression Node
+	Pos        int
+}
+
+func (n *AssignNode) Type() string { return lexer.ASSIGN_NODE }
+
+type FunctionDefenitionNode struct {
+	Identifier  string
+	Parameters  []Node
+	Consequence ProgramNode
+	Pos         int
+}
+
+func (n *FunctionDefenitionNode) Type() string { return lexer.FUNCTION_DEFENITION_NODE }
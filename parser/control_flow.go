@@ -0,0 +1,51 @@
+package parser
+
+import "numerical/lexer"
+
+/* ----------------------------- Control Flow AST ---------------------------- */
+
+type BlockNode struct {
+	Statements []Node
+	Pos        int
+}
+
+func (n *BlockNode) Type() string { return lexer.BLOCK_NODE }
+
+type IfNode struct {
+	Condition   Node
+	Consequence *BlockNode
+	Alternative *BlockNode
+	Pos         int
+}
+
+func (n *IfNode) Type() string { return lexer.IF_NODE }
+
+type WhileNode struct {
+	Condition Node
+	Body      *BlockNode
+	Pos       int
+}
+
+func (n *WhileNode) Type() string { return lexer.WHILE_NODE }
+
+type ForInNode struct {
+	Identifier string
+	Iterable   Node
+	Body       *BlockNode
+	Pos        int
+}
+
+func (n *ForInNode) Type() string { return lexer.FOR_IN_NODE }
+
+type ReturnNode struct {
+	Expression Node
+	Pos        int
+}
+
+func (n *ReturnNode) Type() string { return lexer.RETURN_NODE }
+
+type BreakNode struct {
+	Pos int
+}
+
+func (n *BreakNode) Type() string { return lexer.BREAK_NODE }
@@ -2,8 +2,8 @@ package parser
 
 import (
 	"errors"
+	"numerical/diag"
 	"numerical/lexer"
-	"strconv"
 )
 
 /* ---------------------------- Parser Structure ---------------------------- */
@@ -12,10 +12,24 @@ type Parser struct {
 	tokens   []lexer.Token
 	token    lexer.Token
 	position int
+	source   string
+
+	prefixParseFns map[string]prefixParseFn
+	infixParseFns  map[string]infixParseFn
+	precedences    map[string]int
 }
 
 func NewParser(tokens []lexer.Token) *Parser {
 	p := &Parser{tokens: tokens, token: tokens[0], position: 0}
+	p.registerSyntax()
+	return p
+}
+
+// NewParserWithSource behaves like NewParser but keeps the original source
+// text around so diagnostics can render a caret-underlined snippet.
+func NewParserWithSource(tokens []lexer.Token, source string) *Parser {
+	p := NewParser(tokens)
+	p.source = source
 	return p
 }
 
@@ -32,26 +46,49 @@ func (p *Parser) peekToken() lexer.Token {
 	return p.tokens[p.position+1]
 }
 
-func (p *Parser) Parse() (ProgramNode, error) {
+// Parse walks the whole token stream, collecting every diagnostic it hits
+// instead of bailing out on the first one (mirroring go/parser), so a single
+// pass can report all of a program's syntax errors at once.
+func (p *Parser) Parse() (ProgramNode, []diag.Diagnostic) {
 	ast := ProgramNode{Nodes: make([]Node, 0)}
+	diagnostics := make([]diag.Diagnostic, 0)
+
 	for p.token.Type != lexer.EOF {
 		if p.token.Type == lexer.SEMICOLON {
 			p.advance()
+			continue
 		}
+
 		node, err := p.parseExpression()
 		if err != nil {
-			return ProgramNode{}, err
+			pos := p.token.Pos
+			if errNode, ok := node.(*ErrorNode); ok {
+				pos = errNode.Pos
+			}
+			diagnostics = append(diagnostics, diag.FromError(err, pos, p.source))
+			p.synchronize()
+			continue
 		}
 		ast.Nodes = append(ast.Nodes, node)
 	}
-	return ast, nil
+	return ast, diagnostics
+}
+
+// synchronize discards tokens until the next statement boundary so Parse can
+// keep going after a syntax error instead of aborting the whole program.
+func (p *Parser) synchronize() {
+	for p.token.Type != lexer.EOF && p.token.Type != lexer.SEMICOLON {
+		p.advance()
+	}
 }
 
 func (p *Parser) parseExpression() (Node, error) {
+	pos := p.token.Pos
+
 	if p.token.Type == lexer.IDENTIFIER && p.peekToken().Type == lexer.EQ {
 		expr, err := p.parseAssignment()
 		if err != nil {
-			return &ErrorNode{}, err
+			return &ErrorNode{Pos: pos}, err
 		}
 		return expr, nil
 	}
@@ -59,20 +96,42 @@ func (p *Parser) parseExpression() (Node, error) {
 	if p.token.Type == lexer.DEFINE {
 		expr, err := p.parseFunctionDefenition()
 		if err != nil {
-			return &ErrorNode{}, err
+			return &ErrorNode{Pos: pos}, err
 		}
 		return expr, nil
 	}
 
+	if p.token.Type == lexer.IF {
+		return p.parseIf()
+	}
+
+	if p.token.Type == lexer.WHILE {
+		return p.parseWhile()
+	}
+
+	if p.token.Type == lexer.FOR {
+		return p.parseForIn()
+	}
+
+	if p.token.Type == lexer.RETURN {
+		return p.parseReturn()
+	}
+
+	if p.token.Type == lexer.BREAK {
+		p.advance()
+		return &BreakNode{Pos: pos}, nil
+	}
+
 	expr, err := p.parseExpr(0)
 	if err != nil {
-		return &ErrorNode{}, err
+		return &ErrorNode{Pos: pos}, err
 	}
 	return expr, nil
 }
 
 func (p *Parser) parseFunctionDefenition() (Node, error) {
 	var err error
+	pos := p.token.Pos
 	p.advance()
 	identifer := p.token.Literal
 	p.advance()
@@ -80,179 +139,191 @@ func (p *Parser) parseFunctionDefenition() (Node, error) {
 	if p.token.Type == lexer.LPAREN {
 		params, err = p.parseParameters(lexer.RPAREN)
 		if err != nil {
-			return &ErrorNode{}, err
+			return &ErrorNode{Pos: pos}, err
 		}
 		p.advance()
 	}
 
 	if p.token.Type != lexer.ARROW {
-		return &ErrorNode{}, errors.New("SyntaxError: expected => while parsing functionDefenition")
+		return &ErrorNode{Pos: pos}, errors.New("SyntaxError: expected => while parsing functionDefenition")
 	}
 
 	p.advance()
-	consequence := ProgramNode{Nodes: make([]Node, 0)}
+	consequence := ProgramNode{Nodes: make([]Node, 0), Pos: p.token.Pos}
 	for p.token.Type != lexer.EOF {
 		if p.token.Type == lexer.SEMICOLON {
 			p.advance()
 		}
 		expr, err := p.parseExpression()
 		if err != nil {
-			return &ErrorNode{}, nil
+			return &ErrorNode{Pos: pos}, err
 		}
 		consequence.Nodes = append(consequence.Nodes, expr)
 	}
 
-	return &FunctionDefenitionNode{identifer, params, consequence}, nil
+	return &FunctionDefenitionNode{Identifier: identifer, Parameters: params, Consequence: consequence, Pos: pos}, nil
 
 }
 
-func (p *Parser) parseAssignment() (Node, error) {
-	identifier := p.token.Literal
-	p.advance()
-	p.advance()
-	expr, err := p.parseExpr(0)
-	if err != nil {
-		return &ErrorNode{}, err
+func (p *Parser) parseBlock() (*BlockNode, error) {
+	if p.token.Type != lexer.LBRACE {
+		return &BlockNode{}, errors.New("SyntaxError: expected { while parsing block")
 	}
-	return &AssignNode{identifier, expr}, nil
-}
+	pos := p.token.Pos
+	p.advance()
 
-func (p *Parser) parseExpr(rbp int) (Node, error) {
-	left, err := p.parsePrefix()
-	if err != nil {
-		return &ErrorNode{}, err
-	}
-	peekRbp := preference(p.token.Type)
-	for p.peekToken().Type != lexer.EOF && p.peekToken().Type != lexer.SEMICOLON && peekRbp >= rbp {
-		left, err = p.parseInfix(left, p.token.Type)
-		if err != nil {
-			return &ErrorNode{}, err
+	statements := make([]Node, 0)
+	for p.token.Type != lexer.RBRACE {
+		if p.token.Type == lexer.EOF {
+			return &BlockNode{}, errors.New("SyntaxError: unclosed brace parseBlock()")
 		}
-		peekRbp = preference(p.token.Type)
-	}
-	return left, nil
-}
-
-func (p *Parser) parsePrefix() (Node, error) {
-	switch p.token.Type {
-	case lexer.TILDE:
-		p.advance()
-		expression, err := p.parsePrefix()
-		if err != nil {
-			return &ErrorNode{}, err
+		if p.token.Type == lexer.SEMICOLON {
+			p.advance()
+			continue
 		}
-		return &UnaryOpNode{lexer.TILDE, expression}, nil
 
-	case lexer.NOT:
-		p.advance()
-		expression, err := p.parsePrefix()
+		statement, err := p.parseExpression()
 		if err != nil {
-			return &ErrorNode{}, err
+			return &BlockNode{}, err
 		}
-		return &UnaryOpNode{lexer.NOT, expression}, nil
+		statements = append(statements, statement)
+	}
+	p.advance()
 
-	case lexer.SUB:
-		p.advance()
-		expression, err := p.parsePrefix()
-		if err != nil {
-			return &ErrorNode{}, err
-		}
-		return &UnaryOpNode{lexer.SUB, expression}, nil
+	return &BlockNode{Statements: statements, Pos: pos}, nil
+}
 
-	case lexer.LPAREN:
-		p.advance()
-		expression, err := p.parseExpr(preference(lexer.LPAREN))
-		if err != nil {
-			return &ErrorNode{}, err
-		}
-		p.advance()
-		if p.token.Type == lexer.IDENTIFIER {
-			unit := p.token.Literal
-			p.advance()
-			return &UnitNode{expression, unit}, nil
-		}
-		return expression, nil
+func (p *Parser) parseIf() (Node, error) {
+	pos := p.token.Pos
+	p.advance()
+	condition, err := p.parseExpr(0)
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
 
-	case lexer.LSQUARE:
-		nodes, err := p.parseParameters(lexer.RSQUARE)
-		if err != nil {
-			return &ErrorNode{}, err
-		}
-		p.advance()
-		return &ArrayNode{nodes}, nil
+	consequence, err := p.parseBlock()
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
 
-	case lexer.IDENTIFIER:
-		identifier := p.token.Literal
+	var alternative *BlockNode
+	if p.token.Type == lexer.ELSE {
 		p.advance()
-		if p.token.Type == lexer.LPAREN {
-			params, err := p.parseParameters(lexer.RPAREN)
+		if p.token.Type == lexer.IF {
+			elseIf, err := p.parseIf()
 			if err != nil {
-				return &ErrorNode{}, nil
+				return &ErrorNode{Pos: pos}, err
+			}
+			alternative = &BlockNode{Statements: []Node{elseIf}}
+		} else {
+			alternative, err = p.parseBlock()
+			if err != nil {
+				return &ErrorNode{Pos: pos}, err
 			}
-			p.advance()
-			return &FunctionCallNode{identifier, ProgramNode{params}}, nil
 		}
+	}
 
-		if p.token.Type == lexer.IDENTIFIER {
-			unit := p.token.Literal
-			p.advance()
-			return &UnitNode{&IdentifierNode{Identifier: identifier}, unit}, nil
-		}
-		return &IdentifierNode{identifier}, nil
+	return &IfNode{Condition: condition, Consequence: consequence, Alternative: alternative, Pos: pos}, nil
+}
 
-	case lexer.INT:
-		value, err := strconv.Atoi(p.token.Literal)
-		if err != nil {
-			return &ErrorNode{}, err
-		}
-		p.advance()
-		node := &IntNode{Value: value}
-		if p.token.Type == lexer.IDENTIFIER {
-			unit := p.token.Literal
-			p.advance()
-			return &UnitNode{node, unit}, nil
-		}
-		return node, nil
+func (p *Parser) parseWhile() (Node, error) {
+	pos := p.token.Pos
+	p.advance()
+	condition, err := p.parseExpr(0)
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
 
-	case lexer.FLOAT:
-		value, err := strconv.ParseFloat(p.token.Literal, 64)
-		if err != nil {
-			return &ErrorNode{}, err
-		}
-		p.advance()
-		node := &FloatNode{Value: value}
-		if p.token.Type == lexer.IDENTIFIER {
-			unit := p.token.Literal
-			p.advance()
-			return &UnitNode{node, unit}, nil
-		}
-		return node, nil
+	body, err := p.parseBlock()
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
 
-	case lexer.STRING:
-		value := p.token.Literal
-		p.advance()
-		return &StringNode{value}, nil
+	return &WhileNode{Condition: condition, Body: body, Pos: pos}, nil
+}
+
+func (p *Parser) parseForIn() (Node, error) {
+	pos := p.token.Pos
+	p.advance()
+	if p.token.Type != lexer.IDENTIFIER {
+		return &ErrorNode{Pos: pos}, errors.New("SyntaxError: expected identifier while parsing for")
+	}
+	identifier := p.token.Literal
+	p.advance()
+
+	if p.token.Type != lexer.IN {
+		return &ErrorNode{Pos: pos}, errors.New("SyntaxError: expected in while parsing for")
+	}
+	p.advance()
+
+	iterable, err := p.parseExpr(0)
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
+
+	body, err := p.parseBlock()
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
 	}
-	return &ErrorNode{}, errors.New("SyntaxError: parsePrefix() unsupported prefix:" + p.token.Literal)
+
+	return &ForInNode{Identifier: identifier, Iterable: iterable, Body: body, Pos: pos}, nil
 }
 
-func (p *Parser) parseInfix(left Node, operation string) (Node, error) {
-	if !contains([]string{
-		"EE", "NE", "LT", "GT", "LTE", "GTE", "ADD", "SUB", "MUL", "DIV", "MOD", "POW", "IN", "ARROW",
-	}, p.token.Type) {
-		return &ErrorNode{}, errors.New("SyntaxError: parseInfix() unsupported opperator:" + p.token.Literal)
+func (p *Parser) parseReturn() (Node, error) {
+	pos := p.token.Pos
+	p.advance()
+	if p.token.Type == lexer.SEMICOLON || p.token.Type == lexer.RBRACE || p.token.Type == lexer.EOF {
+		return &ReturnNode{Pos: pos}, nil
 	}
 
+	expression, err := p.parseExpr(0)
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
+	return &ReturnNode{Expression: expression, Pos: pos}, nil
+}
+
+func (p *Parser) parseAssignment() (Node, error) {
+	pos := p.token.Pos
+	identifier := p.token.Literal
 	p.advance()
-	right, err := p.parseExpr(preference(operation) + 1)
+	p.advance()
+	expr, err := p.parseExpr(0)
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
+	return &AssignNode{Identifier: identifier, Expression: expr, Pos: pos}, nil
+}
 
+func (p *Parser) parseExpr(rbp int) (Node, error) {
+	pos := p.token.Pos
+	left, err := p.parsePrefix()
 	if err != nil {
-		return &ErrorNode{}, err
+		return &ErrorNode{Pos: pos}, err
+	}
+	for p.peekToken().Type != lexer.EOF && p.peekToken().Type != lexer.SEMICOLON && p.curPrecedence() >= rbp {
+		left, err = p.parseInfix(left, p.token.Type)
+		if err != nil {
+			return &ErrorNode{Pos: pos}, err
+		}
 	}
-	if operation == lexer.ARROW {
-		operation = lexer.IN
+	return left, nil
+}
+
+func (p *Parser) parsePrefix() (Node, error) {
+	prefix, ok := p.prefixParseFns[p.token.Type]
+	if !ok {
+		return &ErrorNode{Pos: p.token.Pos}, errors.New("SyntaxError: parsePrefix() unsupported prefix:" + p.token.Literal)
 	}
-	return &BinOpNode{Left: left, Operation: operation, Right: right}, nil
+	return prefix()
+}
+
+func (p *Parser) parseInfix(left Node, operation string) (Node, error) {
+	infix, ok := p.infixParseFns[operation]
+	if !ok {
+		return &ErrorNode{Pos: p.token.Pos}, errors.New("SyntaxError: parseInfix() unsupported opperator:" + p.token.Literal)
+	}
+	return infix(left)
 }
 
 func (p *Parser) parseParameters(terminate string) ([]Node, error) {
@@ -277,37 +348,3 @@ func (p *Parser) parseParameters(terminate string) ([]Node, error) {
 	}
 	return parameters, nil
 }
-
-func preference(tokenType string) int {
-	var preferences = map[string]int{
-		lexer.IN:     5,
-		lexer.ARROW:  5,
-		lexer.EE:     10,
-		lexer.NE:     10,
-		lexer.GT:     10,
-		lexer.GTE:    10,
-		lexer.LT:     10,
-		lexer.LTE:    10,
-		lexer.MOD:    15,
-		lexer.ADD:    20,
-		lexer.SUB:    20,
-		lexer.MUL:    30,
-		lexer.DIV:    30,
-		lexer.POW:    40,
-		lexer.LPAREN: 0,
-	}
-
-	if rbp, ok := preferences[tokenType]; ok {
-		return rbp
-	}
-	return -1
-}
-
-func contains(array []string, element string) bool {
-	for _, e := range array {
-		if e == element {
-			return true
-		}
-	}
-	return false
-}
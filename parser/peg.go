@@ -0,0 +1,251 @@
+package parser
+
+import (
+	"errors"
+	"numerical/lexer"
+	"os"
+	"strconv"
+
+	"github.com/yhirose/go-peg"
+)
+
+// operatorSymbols translates the literal operator text the PEG grammar
+// matches (e.g. "+", "==", "in") into the symbolic lexer constants
+// (lexer.ADD, lexer.EE, lexer.IN, ...) that evaluator.binaryOperations and
+// evalUnaryOp switch on. Without this translation every BinOpNode/
+// UnaryOpNode built by this front end carries an Operation the evaluator
+// doesn't recognise.
+var operatorSymbols = map[string]string{
+	"+":  lexer.ADD,
+	"-":  lexer.SUB,
+	"*":  lexer.MUL,
+	"/":  lexer.DIV,
+	"%":  lexer.MOD,
+	"^":  lexer.POW,
+	"==": lexer.EE,
+	"!=": lexer.NE,
+	"<=": lexer.LTE,
+	">=": lexer.GTE,
+	"<":  lexer.LT,
+	">":  lexer.GT,
+	"in": lexer.IN,
+	"=>": lexer.IN,
+	"~":  lexer.TILDE,
+	"!":  lexer.NOT,
+}
+
+func translateOperator(literal string) string {
+	if symbol, ok := operatorSymbols[literal]; ok {
+		return symbol
+	}
+	return literal
+}
+
+/* -------------------------------- PEG Front End -------------------------------- */
+
+// Grammar wraps a compiled PEG parser so user-supplied .peg files can produce
+// the same parser.Node tree as the hand-written Pratt parser in pratt.go,
+// without recompiling NumeriCal. It is a supplement to, not a replacement
+// for, NewParser/Parse - existing callers are unaffected.
+type Grammar struct {
+	parser *peg.Parser
+}
+
+// LoadGrammar compiles the PEG grammar at path (see grammar/numerical.peg for
+// the default) and wires up reduction actions that build the same AST types
+// the recursive-descent parser produces. Callers can supply their own .peg
+// file to extend the language - new literal forms, new operators - as long
+// as it defines every non-terminal registerActions attaches an action to
+// (PROGRAM, ASSIGN, DEFINE, IF, WHILE, FOR, RETURN, BREAK, BLOCK, INFIX,
+// PREFIX, CALL, ARRAY, GROUP, UNIT, IDENTIFIER, INT, FLOAT, STRING).
+func LoadGrammar(path string) (*Grammar, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("GrammarError: failed to read grammar file " + path)
+	}
+
+	p, err := peg.NewParser(string(source))
+	if err != nil {
+		return nil, errors.New("GrammarError: " + err.Error())
+	}
+
+	g := &Grammar{parser: p}
+	g.registerActions()
+
+	return g, nil
+}
+
+// Parse runs source through the PEG grammar and returns the same ProgramNode
+// shape Parse() would, so the evaluator doesn't need to know which front end
+// produced a program.
+func (g *Grammar) Parse(source string) (ProgramNode, error) {
+	value, err := g.parser.ParseAndGetValue(source, nil)
+	if err != nil {
+		return ProgramNode{}, errors.New("SyntaxError: " + err.Error())
+	}
+
+	nodes, ok := value.([]Node)
+	if !ok {
+		return ProgramNode{}, errors.New("GrammarError: PROGRAM action did not return []Node")
+	}
+	return ProgramNode{Nodes: nodes}, nil
+}
+
+func (g *Grammar) registerActions() {
+	rules := g.parser.Grammar
+
+	rules["PROGRAM"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		nodes := make([]Node, len(v.Vs))
+		for i, statement := range v.Vs {
+			nodes[i] = statement.(Node)
+		}
+		return nodes, nil
+	}
+
+	rules["ASSIGN"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		return &AssignNode{Identifier: v.Token(), Expression: v.Vs[0].(Node)}, nil
+	}
+
+	rules["DEFINE"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		identifier := v.ToStr(0)
+		block, ok := v.Vs[len(v.Vs)-1].(*BlockNode)
+		if !ok {
+			return nil, errors.New("GrammarError: DEFINE action expected a block body")
+		}
+
+		params := make([]Node, 0, len(v.Vs)-2)
+		for _, param := range v.Vs[1 : len(v.Vs)-1] {
+			params = append(params, param.(Node))
+		}
+
+		return &FunctionDefenitionNode{
+			Identifier:  identifier,
+			Parameters:  params,
+			Consequence: ProgramNode{Nodes: block.Statements},
+		}, nil
+	}
+
+	rules["IF"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		condition := v.Vs[0].(Node)
+		consequence := v.Vs[1].(*BlockNode)
+
+		node := &IfNode{Condition: condition, Consequence: consequence}
+		if len(v.Vs) > 2 {
+			switch alternative := v.Vs[2].(type) {
+			case *BlockNode:
+				node.Alternative = alternative
+			case Node:
+				node.Alternative = &BlockNode{Statements: []Node{alternative}}
+			}
+		}
+		return node, nil
+	}
+
+	rules["WHILE"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		return &WhileNode{Condition: v.Vs[0].(Node), Body: v.Vs[1].(*BlockNode)}, nil
+	}
+
+	rules["FOR"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		return &ForInNode{Identifier: v.ToStr(0), Iterable: v.Vs[1].(Node), Body: v.Vs[2].(*BlockNode)}, nil
+	}
+
+	rules["RETURN"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		if len(v.Vs) == 0 {
+			return &ReturnNode{}, nil
+		}
+		return &ReturnNode{Expression: v.Vs[0].(Node)}, nil
+	}
+
+	rules["BREAK"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		return &BreakNode{}, nil
+	}
+
+	rules["BLOCK"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		statements := make([]Node, len(v.Vs))
+		for i, statement := range v.Vs {
+			statements[i] = statement.(Node)
+		}
+		return &BlockNode{Statements: statements}, nil
+	}
+
+	rules["INFIX"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		left := v.Vs[0].(Node)
+		for i := 1; i < len(v.Vs); i += 2 {
+			operation := translateOperator(v.ToStr(i))
+			right := v.Vs[i+1].(Node)
+			left = &BinOpNode{Left: left, Operation: operation, Right: right}
+		}
+		return left, nil
+	}
+
+	// PREFIX matches zero or more unary operators, an ATOM, and an optional
+	// trailing UNIT. UNIT.Action returns a bare string rather than a Node, so
+	// the last value is only the atom when no unit suffix was matched.
+	rules["PREFIX"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		if len(v.Vs) == 0 {
+			return nil, errors.New("GrammarError: PREFIX matched no values")
+		}
+
+		atomIndex := len(v.Vs) - 1
+		var node Node
+		if unit, ok := v.Vs[atomIndex].(string); ok {
+			atomIndex--
+			node = &UnitNode{Value: v.Vs[atomIndex].(Node), Unit: unit}
+		} else {
+			node = v.Vs[atomIndex].(Node)
+		}
+
+		for i := atomIndex - 1; i >= 0; i-- {
+			node = &UnaryOpNode{Operation: translateOperator(v.ToStr(i)), Right: node}
+		}
+		return node, nil
+	}
+
+	rules["CALL"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		args := make([]Node, len(v.Vs)-1)
+		for i, arg := range v.Vs[1:] {
+			args[i] = arg.(Node)
+		}
+		return &FunctionCallNode{Identifier: v.ToStr(0), Parameters: ProgramNode{Nodes: args}}, nil
+	}
+
+	rules["ARRAY"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		elements := make([]Node, len(v.Vs))
+		for i, element := range v.Vs {
+			elements[i] = element.(Node)
+		}
+		return &ArrayNode{Elements: elements}, nil
+	}
+
+	rules["GROUP"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		return v.Vs[0].(Node), nil
+	}
+
+	rules["UNIT"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		return v.Token(), nil
+	}
+
+	rules["IDENTIFIER"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		return &IdentifierNode{Identifier: v.Token()}, nil
+	}
+
+	rules["INT"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		value, err := strconv.Atoi(v.Token())
+		if err != nil {
+			return nil, err
+		}
+		return &IntNode{Value: value}, nil
+	}
+
+	rules["FLOAT"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		value, err := strconv.ParseFloat(v.Token(), 64)
+		if err != nil {
+			return nil, err
+		}
+		return &FloatNode{Value: value}, nil
+	}
+
+	rules["STRING"].Action = func(v *peg.Values, d peg.Any) (peg.Any, error) {
+		return &StringNode{Value: v.Token()}, nil
+	}
+}
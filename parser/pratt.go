@@ -0,0 +1,196 @@
+package parser
+
+import (
+	"numerical/lexer"
+	"strconv"
+)
+
+/* ------------------------------ Pratt Registry ------------------------------ */
+
+type prefixParseFn func() (Node, error)
+type infixParseFn func(Node) (Node, error)
+
+func (p *Parser) registerPrefix(tokenType string, fn prefixParseFn) {
+	p.prefixParseFns[tokenType] = fn
+}
+
+func (p *Parser) registerInfix(tokenType string, fn infixParseFn) {
+	p.infixParseFns[tokenType] = fn
+}
+
+func (p *Parser) precedenceOf(tokenType string) int {
+	if precedence, ok := p.precedences[tokenType]; ok {
+		return precedence
+	}
+	return -1
+}
+
+func (p *Parser) curPrecedence() int {
+	return p.precedenceOf(p.token.Type)
+}
+
+func (p *Parser) peekPrecedence() int {
+	return p.precedenceOf(p.peekToken().Type)
+}
+
+// registerSyntax wires up the default NumeriCal grammar. New operators can be
+// added without touching parseExpr/parsePrefix/parseInfix by calling
+// registerPrefix/registerInfix with a token type that has a precedence entry.
+func (p *Parser) registerSyntax() {
+	p.precedences = map[string]int{
+		lexer.IN:     5,
+		lexer.ARROW:  5,
+		lexer.EE:     10,
+		lexer.NE:     10,
+		lexer.GT:     10,
+		lexer.GTE:    10,
+		lexer.LT:     10,
+		lexer.LTE:    10,
+		lexer.MOD:    15,
+		lexer.ADD:    20,
+		lexer.SUB:    20,
+		lexer.MUL:    30,
+		lexer.DIV:    30,
+		lexer.POW:    40,
+		lexer.LPAREN: 0,
+	}
+
+	p.prefixParseFns = make(map[string]prefixParseFn)
+	p.registerPrefix(lexer.TILDE, p.parseTildePrefix)
+	p.registerPrefix(lexer.NOT, p.parseNotPrefix)
+	p.registerPrefix(lexer.SUB, p.parseSubPrefix)
+	p.registerPrefix(lexer.LPAREN, p.parseGroupedExpression)
+	p.registerPrefix(lexer.LSQUARE, p.parseArrayLiteral)
+	p.registerPrefix(lexer.IDENTIFIER, p.parseIdentifierExpression)
+	p.registerPrefix(lexer.INT, p.parseIntLiteral)
+	p.registerPrefix(lexer.FLOAT, p.parseFloatLiteral)
+	p.registerPrefix(lexer.STRING, p.parseStringLiteral)
+
+	p.infixParseFns = make(map[string]infixParseFn)
+	for _, tokenType := range []string{
+		lexer.EE, lexer.NE, lexer.LT, lexer.GT, lexer.LTE, lexer.GTE,
+		lexer.ADD, lexer.SUB, lexer.MUL, lexer.DIV, lexer.MOD, lexer.POW,
+		lexer.IN, lexer.ARROW,
+	} {
+		p.registerInfix(tokenType, p.parseBinOpInfix)
+	}
+}
+
+/* ------------------------------ Prefix Parsers ------------------------------ */
+
+func (p *Parser) parseUnaryPrefix(operation string) (Node, error) {
+	pos := p.token.Pos
+	p.advance()
+	expression, err := p.parsePrefix()
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
+	return &UnaryOpNode{Operation: operation, Right: expression, Pos: pos}, nil
+}
+
+func (p *Parser) parseTildePrefix() (Node, error) { return p.parseUnaryPrefix(lexer.TILDE) }
+func (p *Parser) parseNotPrefix() (Node, error)   { return p.parseUnaryPrefix(lexer.NOT) }
+func (p *Parser) parseSubPrefix() (Node, error)   { return p.parseUnaryPrefix(lexer.SUB) }
+
+func (p *Parser) parseGroupedExpression() (Node, error) {
+	pos := p.token.Pos
+	p.advance()
+	expression, err := p.parseExpr(p.precedenceOf(lexer.LPAREN))
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
+	p.advance()
+	if p.token.Type == lexer.IDENTIFIER {
+		unit := p.token.Literal
+		p.advance()
+		return &UnitNode{Value: expression, Unit: unit, Pos: pos}, nil
+	}
+	return expression, nil
+}
+
+func (p *Parser) parseArrayLiteral() (Node, error) {
+	pos := p.token.Pos
+	nodes, err := p.parseParameters(lexer.RSQUARE)
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
+	p.advance()
+	return &ArrayNode{Elements: nodes, Pos: pos}, nil
+}
+
+func (p *Parser) parseIdentifierExpression() (Node, error) {
+	pos := p.token.Pos
+	identifier := p.token.Literal
+	p.advance()
+	if p.token.Type == lexer.LPAREN {
+		params, err := p.parseParameters(lexer.RPAREN)
+		if err != nil {
+			return &ErrorNode{Pos: pos}, err
+		}
+		p.advance()
+		return &FunctionCallNode{Identifier: identifier, Parameters: ProgramNode{Nodes: params}, Pos: pos}, nil
+	}
+
+	if p.token.Type == lexer.IDENTIFIER {
+		unit := p.token.Literal
+		p.advance()
+		return &UnitNode{Value: &IdentifierNode{Identifier: identifier, Pos: pos}, Unit: unit, Pos: pos}, nil
+	}
+	return &IdentifierNode{Identifier: identifier, Pos: pos}, nil
+}
+
+func (p *Parser) parseIntLiteral() (Node, error) {
+	pos := p.token.Pos
+	value, err := strconv.Atoi(p.token.Literal)
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
+	p.advance()
+	node := &IntNode{Value: value, Pos: pos}
+	if p.token.Type == lexer.IDENTIFIER {
+		unit := p.token.Literal
+		p.advance()
+		return &UnitNode{Value: node, Unit: unit, Pos: pos}, nil
+	}
+	return node, nil
+}
+
+func (p *Parser) parseFloatLiteral() (Node, error) {
+	pos := p.token.Pos
+	value, err := strconv.ParseFloat(p.token.Literal, 64)
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
+	p.advance()
+	node := &FloatNode{Value: value, Pos: pos}
+	if p.token.Type == lexer.IDENTIFIER {
+		unit := p.token.Literal
+		p.advance()
+		return &UnitNode{Value: node, Unit: unit, Pos: pos}, nil
+	}
+	return node, nil
+}
+
+func (p *Parser) parseStringLiteral() (Node, error) {
+	pos := p.token.Pos
+	value := p.token.Literal
+	p.advance()
+	return &StringNode{Value: value, Pos: pos}, nil
+}
+
+/* ------------------------------- Infix Parsers ------------------------------- */
+
+func (p *Parser) parseBinOpInfix(left Node) (Node, error) {
+	pos := p.token.Pos
+	operation := p.token.Type
+	precedence := p.curPrecedence()
+	p.advance()
+	right, err := p.parseExpr(precedence + 1)
+	if err != nil {
+		return &ErrorNode{Pos: pos}, err
+	}
+	if operation == lexer.ARROW {
+		operation = lexer.IN
+	}
+	return &BinOpNode{Left: left, Operation: operation, Right: right, Pos: pos}, nil
+}
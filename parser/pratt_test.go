@@ -0,0 +1,223 @@
+package parser
+
+import (
+	"numerical/lexer"
+	"testing"
+)
+
+// tok builds a lexer.Token without bothering with Pos, since the tests below
+// only care about the resulting AST shape.
+func tok(tokenType, literal string) lexer.Token {
+	return lexer.Token{Type: tokenType, Literal: literal}
+}
+
+func parseSingle(t *testing.T, tokens []lexer.Token) Node {
+	t.Helper()
+	tokens = append(tokens, tok(lexer.EOF, ""))
+	program, diagnostics := NewParser(tokens).Parse()
+	if len(diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diagnostics)
+	}
+	if len(program.Nodes) != 1 {
+		t.Fatalf("expected exactly one node, got %d", len(program.Nodes))
+	}
+	return program.Nodes[0]
+}
+
+func TestParseIntLiteral(t *testing.T) {
+	node := parseSingle(t, []lexer.Token{tok(lexer.INT, "4")})
+	intNode, ok := node.(*IntNode)
+	if !ok {
+		t.Fatalf("expected *IntNode, got %T", node)
+	}
+	if intNode.Value != 4 {
+		t.Errorf("expected 4, got %d", intNode.Value)
+	}
+}
+
+func TestParseFloatLiteral(t *testing.T) {
+	node := parseSingle(t, []lexer.Token{tok(lexer.FLOAT, "3.5")})
+	floatNode, ok := node.(*FloatNode)
+	if !ok {
+		t.Fatalf("expected *FloatNode, got %T", node)
+	}
+	if floatNode.Value != 3.5 {
+		t.Errorf("expected 3.5, got %f", floatNode.Value)
+	}
+}
+
+func TestParseBinOpPrecedence(t *testing.T) {
+	// 1 + 2 * 3 should bind as 1 + (2 * 3)
+	node := parseSingle(t, []lexer.Token{
+		tok(lexer.INT, "1"),
+		tok(lexer.ADD, "+"),
+		tok(lexer.INT, "2"),
+		tok(lexer.MUL, "*"),
+		tok(lexer.INT, "3"),
+	})
+
+	root, ok := node.(*BinOpNode)
+	if !ok {
+		t.Fatalf("expected *BinOpNode, got %T", node)
+	}
+	if root.Operation != lexer.ADD {
+		t.Fatalf("expected top-level ADD, got %s", root.Operation)
+	}
+	right, ok := root.Right.(*BinOpNode)
+	if !ok {
+		t.Fatalf("expected right side to be *BinOpNode, got %T", root.Right)
+	}
+	if right.Operation != lexer.MUL {
+		t.Errorf("expected right side MUL, got %s", right.Operation)
+	}
+}
+
+func TestParseUnaryPrefix(t *testing.T) {
+	node := parseSingle(t, []lexer.Token{tok(lexer.SUB, "-"), tok(lexer.INT, "5")})
+	unary, ok := node.(*UnaryOpNode)
+	if !ok {
+		t.Fatalf("expected *UnaryOpNode, got %T", node)
+	}
+	if unary.Operation != lexer.SUB {
+		t.Errorf("expected SUB, got %s", unary.Operation)
+	}
+}
+
+func TestParseIdentifier(t *testing.T) {
+	node := parseSingle(t, []lexer.Token{tok(lexer.IDENTIFIER, "x")})
+	identifier, ok := node.(*IdentifierNode)
+	if !ok {
+		t.Fatalf("expected *IdentifierNode, got %T", node)
+	}
+	if identifier.Identifier != "x" {
+		t.Errorf("expected x, got %s", identifier.Identifier)
+	}
+}
+
+func TestParseFunctionCall(t *testing.T) {
+	node := parseSingle(t, []lexer.Token{
+		tok(lexer.IDENTIFIER, "sq"),
+		tok(lexer.LPAREN, "("),
+		tok(lexer.INT, "2"),
+		tok(lexer.RPAREN, ")"),
+	})
+	call, ok := node.(*FunctionCallNode)
+	if !ok {
+		t.Fatalf("expected *FunctionCallNode, got %T", node)
+	}
+	if call.Identifier != "sq" {
+		t.Errorf("expected sq, got %s", call.Identifier)
+	}
+	if len(call.Parameters.Nodes) != 1 {
+		t.Errorf("expected 1 parameter, got %d", len(call.Parameters.Nodes))
+	}
+}
+
+func TestParseArrayLiteral(t *testing.T) {
+	node := parseSingle(t, []lexer.Token{
+		tok(lexer.LSQUARE, "["),
+		tok(lexer.INT, "1"),
+		tok(lexer.COMMA, ","),
+		tok(lexer.INT, "2"),
+		tok(lexer.RSQUARE, "]"),
+	})
+	if _, ok := node.(*ArrayNode); !ok {
+		t.Fatalf("expected *ArrayNode, got %T", node)
+	}
+}
+
+func TestParseAssignment(t *testing.T) {
+	node := parseSingle(t, []lexer.Token{
+		tok(lexer.IDENTIFIER, "x"),
+		tok(lexer.EQ, "="),
+		tok(lexer.INT, "1"),
+	})
+	assign, ok := node.(*AssignNode)
+	if !ok {
+		t.Fatalf("expected *AssignNode, got %T", node)
+	}
+	if assign.Identifier != "x" {
+		t.Errorf("expected x, got %s", assign.Identifier)
+	}
+}
+
+func TestParseIfWhileForReturnBreak(t *testing.T) {
+	tests := []struct {
+		name     string
+		tokens   []lexer.Token
+		nodeType string
+	}{
+		{
+			name: "if",
+			tokens: []lexer.Token{
+				tok(lexer.IF, "if"), tok(lexer.INT, "1"),
+				tok(lexer.LBRACE, "{"), tok(lexer.INT, "1"), tok(lexer.RBRACE, "}"),
+			},
+			nodeType: lexer.IF_NODE,
+		},
+		{
+			name: "while",
+			tokens: []lexer.Token{
+				tok(lexer.WHILE, "while"), tok(lexer.INT, "1"),
+				tok(lexer.LBRACE, "{"), tok(lexer.INT, "1"), tok(lexer.RBRACE, "}"),
+			},
+			nodeType: lexer.WHILE_NODE,
+		},
+		{
+			name: "for-in",
+			tokens: []lexer.Token{
+				tok(lexer.FOR, "for"), tok(lexer.IDENTIFIER, "i"), tok(lexer.IN, "in"),
+				tok(lexer.LSQUARE, "["), tok(lexer.INT, "1"), tok(lexer.RSQUARE, "]"),
+				tok(lexer.LBRACE, "{"), tok(lexer.INT, "1"), tok(lexer.RBRACE, "}"),
+			},
+			nodeType: lexer.FOR_IN_NODE,
+		},
+		{
+			name:     "return",
+			tokens:   []lexer.Token{tok(lexer.RETURN, "return"), tok(lexer.INT, "1")},
+			nodeType: lexer.RETURN_NODE,
+		},
+		{
+			name:     "break",
+			tokens:   []lexer.Token{tok(lexer.BREAK, "break")},
+			nodeType: lexer.BREAK_NODE,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := parseSingle(t, tt.tokens)
+			if node.Type() != tt.nodeType {
+				t.Errorf("expected %s, got %s", tt.nodeType, node.Type())
+			}
+		})
+	}
+}
+
+func TestParseFunctionDefenition(t *testing.T) {
+	node := parseSingle(t, []lexer.Token{
+		tok(lexer.DEFINE, "define"), tok(lexer.IDENTIFIER, "sq"),
+		tok(lexer.LPAREN, "("), tok(lexer.IDENTIFIER, "x"), tok(lexer.RPAREN, ")"),
+		tok(lexer.ARROW, "=>"),
+		tok(lexer.IDENTIFIER, "x"), tok(lexer.MUL, "*"), tok(lexer.IDENTIFIER, "x"),
+	})
+	def, ok := node.(*FunctionDefenitionNode)
+	if !ok {
+		t.Fatalf("expected *FunctionDefenitionNode, got %T", node)
+	}
+	if def.Identifier != "sq" {
+		t.Errorf("expected sq, got %s", def.Identifier)
+	}
+}
+
+func TestParseCollectsMultipleDiagnostics(t *testing.T) {
+	tokens := []lexer.Token{
+		tok(lexer.MUL, "*"), tok(lexer.SEMICOLON, ";"),
+		tok(lexer.DIV, "/"), tok(lexer.SEMICOLON, ";"),
+	}
+	tokens = append(tokens, tok(lexer.EOF, ""))
+	_, diagnostics := NewParser(tokens).Parse()
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %v", len(diagnostics), diagnostics)
+	}
+}
@@ -0,0 +1,65 @@
+package vm
+
+import (
+	"errors"
+	"math"
+	"numerical/compiler"
+	"numerical/evaluator"
+)
+
+func evalBinaryOp(op compiler.Opcode, left evaluator.Object, right evaluator.Object) (evaluator.Object, error) {
+	if leftNumber, ok := left.(evaluator.Number); ok {
+		if rightNumber, ok := right.(evaluator.Number); ok {
+			return evalNumberOp(op, leftNumber, rightNumber)
+		}
+	}
+
+	if leftString, ok := left.(*evaluator.String); ok {
+		if rightString, ok := right.(*evaluator.String); ok && op == compiler.OP_ADD {
+			return &evaluator.String{Value: leftString.Value + rightString.Value}, nil
+		}
+	}
+
+	return nil, errors.New("VMError: unsupported operand types " + left.Type() + "/" + right.Type())
+}
+
+func evalNumberOp(op compiler.Opcode, left evaluator.Number, right evaluator.Number) (evaluator.Object, error) {
+	l := left.Inspect()
+	r := right.Inspect()
+
+	switch op {
+	case compiler.OP_ADD:
+		return evaluator.NewNumber(l + r), nil
+	case compiler.OP_SUB:
+		return evaluator.NewNumber(l - r), nil
+	case compiler.OP_MUL:
+		return evaluator.NewNumber(l * r), nil
+	case compiler.OP_DIV:
+		return evaluator.NewNumber(l / r), nil
+	case compiler.OP_POW:
+		return evaluator.NewNumber(math.Pow(l, r)), nil
+	case compiler.OP_MOD:
+		return evaluator.NewNumber(math.Mod(l, r)), nil
+	case compiler.OP_CMP_EQ:
+		return evaluator.NewNumber(boolToFloat(l == r)), nil
+	case compiler.OP_CMP_NE:
+		return evaluator.NewNumber(boolToFloat(l != r)), nil
+	case compiler.OP_CMP_LT:
+		return evaluator.NewNumber(boolToFloat(l < r)), nil
+	case compiler.OP_CMP_GT:
+		return evaluator.NewNumber(boolToFloat(l > r)), nil
+	case compiler.OP_CMP_LTE:
+		return evaluator.NewNumber(boolToFloat(l <= r)), nil
+	case compiler.OP_CMP_GTE:
+		return evaluator.NewNumber(boolToFloat(l >= r)), nil
+	}
+
+	return nil, errors.New("VMError: unsupported numeric opcode")
+}
+
+func boolToFloat(value bool) float64 {
+	if value {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,176 @@
+package vm
+
+import (
+	"errors"
+	"numerical/compiler"
+	"numerical/evaluator"
+)
+
+const stackSize = 2048
+
+// frame records where to resume once a call to a user-defined function
+// returns. Compiled functions share the caller's flat global slot table
+// instead of getting their own locals, so a frame only needs the address.
+type frame struct {
+	returnAddress int
+}
+
+// VM runs compiled Bytecode over a value stack and a flat slot array for
+// globals, instead of re-walking the AST with evaluator.Eval on every pass.
+// It covers the subset of the language the compiler understands: arithmetic,
+// comparisons, assignment, if/while/for-in control flow, and calls to
+// user-defined functions.
+type VM struct {
+	constants    []evaluator.Object
+	instructions []byte
+
+	stack []evaluator.Object
+	sp    int
+
+	globals []evaluator.Object
+	frames  []frame
+}
+
+func New(bc *compiler.Bytecode) *VM {
+	return &VM{
+		constants:    bc.Constants,
+		instructions: bc.Instructions,
+		stack:        make([]evaluator.Object, stackSize),
+		globals:      make([]evaluator.Object, bc.NumSymbols),
+	}
+}
+
+func (vm *VM) push(object evaluator.Object) error {
+	if vm.sp >= stackSize {
+		return errors.New("VMError: stack overflow")
+	}
+	vm.stack[vm.sp] = object
+	vm.sp++
+	return nil
+}
+
+func (vm *VM) pop() evaluator.Object {
+	vm.sp--
+	return vm.stack[vm.sp]
+}
+
+// Run executes the program from the start and returns the last value left
+// on the stack (the numerical REPL's "value of the last statement").
+func (vm *VM) Run() (evaluator.Object, error) {
+	ip := 0
+	for ip < len(vm.instructions) {
+		op := compiler.Opcode(vm.instructions[ip])
+		ip++
+
+		switch op {
+		case compiler.OP_CONST:
+			index := int(readUint16(vm.instructions[ip:]))
+			ip += 2
+			if err := vm.push(vm.constants[index]); err != nil {
+				return nil, err
+			}
+
+		case compiler.OP_LOAD:
+			index := int(readUint16(vm.instructions[ip:]))
+			ip += 2
+			if err := vm.push(vm.globals[index]); err != nil {
+				return nil, err
+			}
+
+		case compiler.OP_STORE:
+			index := int(readUint16(vm.instructions[ip:]))
+			ip += 2
+			vm.globals[index] = vm.pop()
+
+		case compiler.OP_ADD, compiler.OP_SUB, compiler.OP_MUL, compiler.OP_DIV, compiler.OP_POW, compiler.OP_MOD,
+			compiler.OP_CMP_EQ, compiler.OP_CMP_NE, compiler.OP_CMP_LT, compiler.OP_CMP_GT, compiler.OP_CMP_LTE, compiler.OP_CMP_GTE:
+			right := vm.pop()
+			left := vm.pop()
+			result, err := evalBinaryOp(op, left, right)
+			if err != nil {
+				return nil, err
+			}
+			if err := vm.push(result); err != nil {
+				return nil, err
+			}
+
+		case compiler.OP_JUMP:
+			ip = int(readUint16(vm.instructions[ip:]))
+
+		case compiler.OP_JUMP_IF_FALSE:
+			target := int(readUint16(vm.instructions[ip:]))
+			ip += 2
+			if !isTruthy(vm.pop()) {
+				ip = target
+			}
+
+		case compiler.OP_RETURN:
+			if len(vm.frames) == 0 {
+				return vm.pop(), nil
+			}
+			returnFrame := vm.frames[len(vm.frames)-1]
+			vm.frames = vm.frames[:len(vm.frames)-1]
+			ip = returnFrame.returnAddress
+
+		case compiler.OP_POP:
+			vm.pop()
+
+		case compiler.OP_CALL:
+			slot := int(readUint16(vm.instructions[ip:]))
+			ip += 2
+			numArgs := int(vm.instructions[ip])
+			ip++
+
+			function, ok := vm.globals[slot].(*evaluator.CompiledFunction)
+			if !ok {
+				return nil, errors.New("VMError: OP_CALL target is not a compiled function")
+			}
+			if numArgs != function.NumParameters {
+				return nil, errors.New("VMError: function called with the wrong number of arguments")
+			}
+
+			vm.frames = append(vm.frames, frame{returnAddress: ip})
+			ip = function.EntryPoint
+
+		case compiler.OP_UNIT_CONVERT:
+			return nil, errors.New("VMError: " + opcodeName(op) + " is not implemented by the vm yet")
+
+		default:
+			return nil, errors.New("VMError: unknown opcode")
+		}
+	}
+
+	if vm.sp > 0 {
+		return vm.stack[vm.sp-1], nil
+	}
+	return &evaluator.Nil{}, nil
+}
+
+func readUint16(instructions []byte) uint16 {
+	return uint16(instructions[0])<<8 | uint16(instructions[1])
+}
+
+func opcodeName(op compiler.Opcode) string {
+	switch op {
+	case compiler.OP_CALL:
+		return "OP_CALL"
+	case compiler.OP_UNIT_CONVERT:
+		return "OP_UNIT_CONVERT"
+	default:
+		return "opcode"
+	}
+}
+
+func isTruthy(object evaluator.Object) bool {
+	switch o := object.(type) {
+	case *evaluator.Integer:
+		return o.Value != 0
+	case *evaluator.Float:
+		return o.Value != 0
+	case *evaluator.String:
+		return o.Value != ""
+	case *evaluator.Nil:
+		return false
+	}
+	return true
+}